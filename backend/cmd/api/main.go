@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"net/http"
@@ -11,6 +12,9 @@ import (
 	"github.com/rs/cors"
 	"github.com/user/auth-app/internal/database"
 	"github.com/user/auth-app/internal/handlers"
+	"github.com/user/auth-app/internal/providers"
+	"github.com/user/auth-app/internal/telephony"
+	"github.com/user/auth-app/internal/zipref"
 )
 
 func main() {
@@ -33,6 +37,27 @@ func main() {
 		log.Printf("Warning: Failed to run migrations: %v", err)
 	}
 
+	// Expire abandoned validation sessions and age the work queue in the background
+	workflow, err := providers.LoadConfig()
+	if err != nil {
+		log.Fatal("Invalid workflow configuration:", err)
+	}
+	providers.SetConfig(workflow)
+	go providers.RunSessionReaper(context.Background())
+	go providers.RunQueueAging(context.Background())
+
+	// Click-to-dial; the fake dialer simulates calls when no carrier is configured
+	dialer, err := telephony.New(telephony.LoadConfig())
+	if err != nil {
+		log.Fatal("Failed to configure telephony:", err)
+	}
+	providers.SetDialer(dialer)
+
+	// Optional 5-digit ZIP reference with city and county
+	if err := zipref.LoadFromEnv(); err != nil {
+		log.Fatal("Failed to load ZIP reference:", err)
+	}
+
 	r := mux.NewRouter()
 
 	// Health check endpoint with database connectivity
@@ -68,10 +93,65 @@ func main() {
 	// Provider routes (all require authentication)
 	r.HandleFunc("/api/providers/next", handlers.AuthMiddleware(handlers.GetNextProvider)).Methods("GET")
 	r.HandleFunc("/api/providers/stats", handlers.AuthMiddleware(handlers.GetProviderStats)).Methods("GET")
+	r.HandleFunc("/api/providers/search", handlers.SupervisorMiddleware(handlers.SearchProviders)).Methods("GET")
+	r.HandleFunc("/api/providers/{provider}", handlers.SupervisorMiddleware(handlers.GetProvider)).Methods("GET")
 	r.HandleFunc("/api/sessions/{sessionId}/validate", handlers.AuthMiddleware(handlers.UpdateValidation)).Methods("PUT")
 	r.HandleFunc("/api/sessions/{sessionId}/call-attempt", handlers.AuthMiddleware(handlers.RecordCallAttempt)).Methods("POST")
+	r.HandleFunc("/api/sessions/{sessionId}/call-attempts", handlers.AuthMiddleware(handlers.GetCallAttemptStatus)).Methods("GET")
 	r.HandleFunc("/api/sessions/{sessionId}/preview", handlers.AuthMiddleware(handlers.GetValidationPreview)).Methods("GET")
+	r.HandleFunc("/api/sessions/{sessionId}/phones/{phoneId}/propagation", handlers.AuthMiddleware(handlers.PreviewPhonePropagation)).Methods("GET")
+	r.HandleFunc("/api/sessions/{sessionId}/addresses/{addressId}/propagation", handlers.AuthMiddleware(handlers.PreviewAddressPropagation)).Methods("GET")
 	r.HandleFunc("/api/sessions/{sessionId}/complete", handlers.AuthMiddleware(handlers.CompleteValidation)).Methods("POST")
+	r.HandleFunc("/api/sessions/{sessionId}/heartbeat", handlers.AuthMiddleware(handlers.HeartbeatSession)).Methods("POST")
+	r.HandleFunc("/api/sessions/{sessionId}/release", handlers.AuthMiddleware(handlers.ReleaseSession)).Methods("POST")
+	r.HandleFunc("/api/sessions/{sessionId}/hold", handlers.AuthMiddleware(handlers.HoldSession)).Methods("POST")
+	r.HandleFunc("/api/sessions/{sessionId}/unreachable", handlers.AuthMiddleware(handlers.MarkUnreachable)).Methods("POST")
+	r.HandleFunc("/api/sessions/callbacks", handlers.AuthMiddleware(handlers.ListCallbacks)).Methods("GET")
+
+	// Telephony routes; carriers authenticate webhooks with a signature instead of a token
+	r.HandleFunc("/api/sessions/{sessionId}/dial", handlers.AuthMiddleware(handlers.StartCall)).Methods("POST")
+	r.HandleFunc("/api/sessions/{sessionId}/calls", handlers.AuthMiddleware(handlers.ListSessionCalls)).Methods("GET")
+	r.HandleFunc("/api/calls/{callId}/hangup", handlers.AuthMiddleware(handlers.HangupCall)).Methods("POST")
+	r.HandleFunc("/api/telephony/events", handlers.TelephonyWebhook).Methods("POST")
+
+	// Global phone flags
+	r.HandleFunc("/api/flagged-phones", handlers.AuthMiddleware(handlers.FlagPhone)).Methods("POST")
+	r.HandleFunc("/api/flagged-phones", handlers.AuthMiddleware(handlers.ListFlaggedPhones)).Methods("GET")
+
+	// ZIP reference, for auto-filling address corrections
+	r.HandleFunc("/api/reference/zips/{zip}", handlers.AuthMiddleware(handlers.LookupZip)).Methods("GET")
+
+	// Business calendar routes
+	r.HandleFunc("/api/calendar/holidays", handlers.AuthMiddleware(handlers.GetHolidays)).Methods("GET")
+	r.HandleFunc("/api/calendar/next-business-day", handlers.AuthMiddleware(handlers.GetNextBusinessDay)).Methods("GET")
+
+	// Supervisor routes
+	r.HandleFunc("/api/admin/queue", handlers.SupervisorMiddleware(handlers.GetQueuePreview)).Methods("GET")
+	r.HandleFunc("/api/admin/providers/{providerId}/priority", handlers.SupervisorMiddleware(handlers.SetProviderPriority)).Methods("PUT")
+	r.HandleFunc("/api/admin/providers/{providerId}/disposition", handlers.SupervisorMiddleware(handlers.ClearDisposition)).Methods("DELETE")
+	r.HandleFunc("/api/admin/exports/outcomes", handlers.SupervisorMiddleware(handlers.ExportOutcomes)).Methods("GET")
+	r.HandleFunc("/api/admin/users/{userId}/skills", handlers.SupervisorMiddleware(handlers.GetUserSkills)).Methods("GET")
+	r.HandleFunc("/api/admin/users/{userId}/skills", handlers.SupervisorMiddleware(handlers.SetUserSkills)).Methods("PUT")
+	r.HandleFunc("/api/admin/call-policies", handlers.SupervisorMiddleware(handlers.ListCallAttemptPolicies)).Methods("GET")
+	r.HandleFunc("/api/admin/call-policies/{campaign}", handlers.SupervisorMiddleware(handlers.SetCallAttemptPolicy)).Methods("PUT")
+	r.HandleFunc("/api/admin/call-policies/{campaign}", handlers.SupervisorMiddleware(handlers.DeleteCallAttemptPolicy)).Methods("DELETE")
+	r.HandleFunc("/api/admin/calendar/closures", handlers.SupervisorMiddleware(handlers.ListClosures)).Methods("GET")
+	r.HandleFunc("/api/admin/calendar/closures", handlers.SupervisorMiddleware(handlers.AddClosure)).Methods("POST")
+	r.HandleFunc("/api/admin/calendar/closures/{closureId}", handlers.SupervisorMiddleware(handlers.DeleteClosure)).Methods("DELETE")
+	r.HandleFunc("/api/admin/flagged-phones/{flagId}/resolve", handlers.SupervisorMiddleware(handlers.ResolveFlag)).Methods("POST")
+	r.HandleFunc("/api/admin/propagations", handlers.SupervisorMiddleware(handlers.ListAddressPropagations)).Methods("GET")
+	r.HandleFunc("/api/admin/propagations/{propagationId}/undo", handlers.SupervisorMiddleware(handlers.UndoAddressPropagation)).Methods("POST")
+	r.HandleFunc("/api/admin/addresses/standardize", handlers.SupervisorMiddleware(handlers.StandardizeAddresses)).Methods("POST")
+	r.HandleFunc("/api/admin/reports/duplicate-addresses", handlers.SupervisorMiddleware(handlers.ListDuplicateAddresses)).Methods("GET")
+	r.HandleFunc("/api/admin/reports/area-code-mismatches", handlers.SupervisorMiddleware(handlers.ListAreaCodeMismatches)).Methods("GET")
+	r.HandleFunc("/api/admin/reports/invalid-npis", handlers.SupervisorMiddleware(handlers.ListInvalidNPIs)).Methods("GET")
+	r.HandleFunc("/api/admin/reports/npi-quarantine", handlers.SupervisorMiddleware(handlers.ListQuarantinedNPIs)).Methods("GET")
+	r.HandleFunc("/api/admin/sessions", handlers.SupervisorMiddleware(handlers.ListActiveSessions)).Methods("GET")
+	r.HandleFunc("/api/admin/sessions/{sessionId}/release", handlers.SupervisorMiddleware(handlers.ForceReleaseSession)).Methods("POST")
+	r.HandleFunc("/api/admin/sessions/{sessionId}/reassign", handlers.SupervisorMiddleware(handlers.ReassignSession)).Methods("POST")
+
+	// Admin routes
+	r.HandleFunc("/api/admin/users/{userId}/role", handlers.AdminMiddleware(handlers.SetUserRole)).Methods("PUT")
 
 	corsOrigins := os.Getenv("CORS_ORIGINS")
 	if corsOrigins == "" {
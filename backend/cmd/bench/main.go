@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/npi"
+	"github.com/user/auth-app/internal/providers"
+)
+
+// Benchmarks GetNextProvider claim latency as the work queue grows.
+// Synthetic providers are tagged with metadata.benchmark and removed afterwards.
+// It refuses to run against a database that already holds providers.
+func main() {
+	var steps = flag.Int("steps", 5, "Number of growth steps")
+	var batch = flag.Int("batch", 100000, "Providers added per step")
+	var claims = flag.Int("claims", 200, "Claims measured per step")
+	var cleanup = flag.Bool("cleanup", true, "Delete synthetic providers when done")
+	flag.Parse()
+
+	if *steps < 1 || *batch < 1 || *claims < 1 {
+		log.Fatal("steps, batch and claims must all be positive")
+	}
+
+	// Load database configuration
+	config := database.LoadConfig()
+
+	// Initialize PostgreSQL connection pool
+	if err := database.InitDB(config); err != nil {
+		log.Fatal("Failed to initialize database:", err)
+	}
+	defer database.Close()
+
+	// Synthetic providers must be claimable regardless of the time of day
+	workflow, err := providers.LoadConfig()
+	if err != nil {
+		log.Fatal("Invalid workflow configuration:", err)
+	}
+	workflow.CallWindowEnabled = false
+	providers.SetConfig(workflow)
+
+	ctx := context.Background()
+
+	var existing bool
+	err = database.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM providers)`).Scan(&existing)
+	if err != nil {
+		log.Fatal("Failed to inspect providers:", err)
+	}
+	if existing {
+		log.Fatal("Database already holds providers; run the benchmark against an empty database")
+	}
+
+	userID, err := benchmarkUser(ctx)
+	if err != nil {
+		log.Fatal("Failed to create benchmark user:", err)
+	}
+
+	if *cleanup {
+		defer func() {
+			if err := removeSyntheticProviders(ctx); err != nil {
+				log.Printf("Failed to remove synthetic providers: %v", err)
+			}
+		}()
+	}
+
+	fmt.Printf("%12s %10s %10s %10s %10s\n", "queue_size", "p50", "p95", "p99", "max")
+	seeded := 0
+	for step := 0; step < *steps; step++ {
+		if err := seedProviders(ctx, seeded, *batch); err != nil {
+			log.Fatal("Failed to seed providers:", err)
+		}
+		seeded += *batch
+
+		var queueSize int
+		if err := database.QueryRow(ctx, "SELECT COUNT(*) FROM work_queue").Scan(&queueSize); err != nil {
+			log.Fatal("Failed to count work queue:", err)
+		}
+
+		latencies, err := measureClaims(userID, *claims)
+		if err != nil {
+			log.Fatal("Failed to measure claims:", err)
+		}
+
+		fmt.Printf("%12d %10s %10s %10s %10s\n", queueSize,
+			percentile(latencies, 0.50), percentile(latencies, 0.95),
+			percentile(latencies, 0.99), latencies[len(latencies)-1])
+	}
+}
+
+// benchmarkUser returns the ID of a dedicated benchmark agent, creating it if needed
+func benchmarkUser(ctx context.Context) (int, error) {
+	var userID int
+	err := database.QueryRow(ctx, `
+		INSERT INTO users (uuid, email, password, is_active)
+		VALUES ($1, 'benchmark@localhost', '!', true)
+		ON CONFLICT (email) DO UPDATE SET updated_at = CURRENT_TIMESTAMP
+		RETURNING id
+	`, uuid.New()).Scan(&userID)
+	return userID, err
+}
+
+// seedProviders inserts count synthetic providers, each with one unvalidated address and phone
+func seedProviders(ctx context.Context, offset, count int) error {
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		rows := pgx.CopyFromSlice(count, func(i int) ([]interface{}, error) {
+			return []interface{}{
+				uuid.New(),
+				npi.Complete(fmt.Sprintf("9%08d", offset+i)),
+				fmt.Sprintf("Benchmark Provider %d", offset+i),
+				map[string]interface{}{"benchmark": true},
+				1 + (offset+i)%10,
+			}, nil
+		})
+		_, err := tx.CopyFrom(ctx, pgx.Identifier{"providers"},
+			[]string{"uuid", "npi", "provider_name", "metadata", "priority"}, rows)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO provider_addresses (provider_id, address1, city, state, zip)
+			SELECT id, '1 Benchmark Way', 'Springfield', 'IL', '62701'
+			FROM providers
+			WHERE metadata @> '{"benchmark": true}'
+			  AND NOT EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = providers.id)
+		`)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO provider_phones (provider_id, phone)
+			SELECT id, '+12175552368'
+			FROM providers
+			WHERE metadata @> '{"benchmark": true}'
+			  AND NOT EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = providers.id)
+		`)
+		return err
+	})
+}
+
+// measureClaims claims and releases providers, returning sorted claim latencies
+func measureClaims(userID, claims int) ([]time.Duration, error) {
+	latencies := make([]time.Duration, 0, claims)
+	release := models.ReleaseSessionRequest{ReasonCode: "other", Notes: "benchmark"}
+
+	for i := 0; i < claims; i++ {
+		start := time.Now()
+		data, err := providers.GetNextProvider(userID)
+		if err != nil {
+			return nil, err
+		}
+		latencies = append(latencies, time.Since(start))
+
+		if err := providers.ReleaseSession(data.ValidationSession.ID, userID, release); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return latencies, nil
+}
+
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	index := int(float64(len(sorted)-1) * p)
+	return sorted[index].Round(time.Microsecond)
+}
+
+func removeSyntheticProviders(ctx context.Context) error {
+	return database.Exec(ctx, `DELETE FROM providers WHERE metadata @> '{"benchmark": true}'`)
+}
@@ -6,11 +6,18 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/address"
 	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/npi"
+	"github.com/user/auth-app/internal/phone"
+	"github.com/user/auth-app/internal/zipref"
 )
 
 func main() {
@@ -39,6 +46,27 @@ func main() {
 		return
 	}
 
+	// Optional 5-digit ZIP reference with city and county
+	if err := zipref.LoadFromEnv(); err != nil {
+		log.Fatal("Failed to load ZIP reference:", err)
+	}
+
+	// Default queue priority and deadline for this import
+	if p, ok := parsePriority(os.Getenv("IMPORT_PRIORITY")); ok {
+		defaultPriority = p
+	}
+	if d, ok := parseDueDate(os.Getenv("IMPORT_DUE_DATE")); ok {
+		defaultDueDate = d
+	}
+	// Rows with an invalid NPI are quarantined unless told to drop them
+	switch action := os.Getenv("INVALID_NPI_ACTION"); action {
+	case "", "quarantine":
+	case "reject":
+		quarantineInvalidNPIs = false
+	default:
+		log.Fatalf("INVALID_NPI_ACTION must be quarantine or reject, not %q", action)
+	}
+
 	// Check if data already exists
 	ctx := context.Background()
 	var providerCount int
@@ -157,17 +185,44 @@ func processBatch(ctx context.Context, tx pgx.Tx, batch [][]string, providers ma
 		addressStatus := strings.TrimSpace(record[13])
 		phoneStatus := strings.TrimSpace(record[14])
 
+		// Optional queue priority and deadline columns
+		queue := queueFields{Priority: defaultPriority, DueDate: defaultDueDate}
+		if len(record) > 15 {
+			if p, ok := parsePriority(record[15]); ok {
+				queue.Priority, queue.PriorityGiven = p, true
+			}
+		}
+		if len(record) > 16 {
+			if d, ok := parseDueDate(record[16]); ok {
+				queue.DueDate, queue.DueDateGiven = d, true
+			}
+		}
+		// Optional languages spoken at the office, for skill routing
+		var languages []string
+		if len(record) > 17 {
+			languages = parseLanguages(record[17])
+		}
+
 		// Validate required fields
 		if npi == "" || firstName == "" || lastName == "" {
 			log.Printf("Skipping record %d: missing required fields", batchOffset+idx)
 			continue
 		}
+		if err := validateNPI(npi); err != nil {
+			log.Printf("Skipping record %d: NPI %s: %v", batchOffset+idx, npi, err)
+			if quarantineInvalidNPIs {
+				if err := quarantineRecord(ctx, tx, record, batchOffset+idx, err); err != nil {
+					return err
+				}
+			}
+			continue
+		}
 
 		// Get or create provider
 		providerID, exists := providers[npi]
 		if !exists {
 			var err error
-			providerID, err = createProvider(ctx, tx, npi, gnpi, firstName, lastName, specialty, groupName)
+			providerID, err = createProvider(ctx, tx, npi, gnpi, firstName, lastName, specialty, groupName, queue, languages)
 			if err != nil {
 				log.Printf("Failed to create provider %s: %v", npi, err)
 				continue
@@ -178,17 +233,35 @@ func processBatch(ctx context.Context, tx pgx.Tx, batch [][]string, providers ma
 		// Generate unique link ID for this address-phone pair
 		linkID := fmt.Sprintf("%d-%d-%d", providerID, batchOffset+idx, len(*addresses))
 
-		// Prepare address record
+		// Prepare address record in USPS standard form; a state or ZIP that
+		// cannot be standardized is left empty
+		std, err := address.Standardize(address.Address{
+			Address1: address1, Address2: address2, City: city, State: state, Zip: zip,
+		})
+		if err != nil {
+			log.Printf("Record %d: %v (state %q, zip %q)", batchOffset+idx, err, state, zip)
+		}
+		// Addresses whose ZIP disagrees with the state or city are loaded but
+		// flagged for the agent
+		metadata := map[string]interface{}{}
+		if issues := zipref.Check(std.State, std.City, std.Zip); len(issues) > 0 {
+			metadata["reference_issues"] = issues
+			for _, issue := range issues {
+				log.Printf("Record %d: %s", batchOffset+idx, issue.Message)
+			}
+		}
 		addressRecord := AddressRecord{
 			ProviderID:      providerID,
 			AddressCategory: normalizeAddressCategory(addressCategory),
-			Address1:        address1,
-			Address2:        nullIfEmpty(address2),
-			City:            nullIfEmpty(city),
-			State:           normalizeState(state),
-			Zip:             normalizeZip(zip),
+			Address1:        std.Address1,
+			Address2:        nullIfEmpty(std.Address2),
+			City:            nullIfEmpty(std.City),
+			State:           normalizeState(std.State),
+			Zip:             normalizeZip(std.Zip),
+			Key:             address.Key(std),
 			IsCorrect:       parseValidationStatus(addressStatus),
 			LinkID:          linkID,
+			Metadata:        metadata,
 		}
 		*addresses = append(*addresses, addressRecord)
 
@@ -196,10 +269,29 @@ func processBatch(ctx context.Context, tx pgx.Tx, batch [][]string, providers ma
 		if phone != "" && phone != "null" {
 			phoneRecord := PhoneRecord{
 				ProviderID: providerID,
-				Phone:      normalizePhone(phone),
 				PhoneType:  "office",
-				IsCorrect:  parseValidationStatus(phoneStatus),
 				LinkID:     linkID,
+				Metadata:   map[string]interface{}{},
+			}
+			number, err := normalizePhone(phone)
+			if err == nil {
+				phoneRecord.Phone = number.E164()
+				phoneRecord.Extension = nullIfEmpty(number.Extension)
+				phoneRecord.IsCorrect = parseValidationStatus(phoneStatus)
+			} else {
+				// Numbers that cannot be dialed are loaded as found, flagged
+				// and unvalidated, so an agent corrects them
+				stored, ok := rawPhone(phone)
+				if !ok {
+					log.Printf("Record %d: phone %q has no digits; treating it as missing", batchOffset+idx, phone)
+					continue
+				}
+				log.Printf("Record %d: flagging phone %q: %v", batchOffset+idx, phone, err)
+				reason := err.Error()
+				phoneRecord.Phone = stored
+				phoneRecord.IsFlagged = true
+				phoneRecord.FlagReason = &reason
+				phoneRecord.Metadata["loaded_phone"] = phone
 			}
 			*phones = append(*phones, phoneRecord)
 		}
@@ -208,20 +300,45 @@ func processBatch(ctx context.Context, tx pgx.Tx, batch [][]string, providers ma
 	return nil
 }
 
-func createProvider(ctx context.Context, tx pgx.Tx, npi, gnpi, firstName, lastName, specialty, groupName string) (int, error) {
+// quarantineRecord keeps a row whose NPI failed validation, with the reason,
+// for review instead of loading it
+func quarantineRecord(ctx context.Context, tx pgx.Tx, record []string, rowNumber int, reason error) error {
+	providerName := strings.TrimSpace(strings.TrimSpace(record[4]) + " " + strings.TrimSpace(record[5]))
+	_, err := tx.Exec(ctx, `
+		INSERT INTO npi_quarantine (npi, provider_name, reason, record, row_number)
+		VALUES ($1, $2, $3, $4, $5)
+	`, strings.TrimSpace(record[0]), nullIfEmpty(providerName), reason.Error(), record, rowNumber)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine record %d: %w", rowNumber, err)
+	}
+	return nil
+}
+
+func createProvider(ctx context.Context, tx pgx.Tx, npi, gnpi, firstName, lastName, specialty, groupName string,
+	queue queueFields, languages []string) (int, error) {
 	providerName := strings.TrimSpace(firstName + " " + lastName)
+	metadata := map[string]interface{}{}
+	if len(languages) > 0 {
+		metadata["languages"] = languages
+	}
 	
+	// A provider already on file keeps the priority and due date a supervisor
+	// may have set, unless this row names new ones
 	var providerID int
 	err := tx.QueryRow(ctx, `
-		INSERT INTO providers (uuid, npi, gnpi, provider_name, specialty, provider_group, is_active)
-		VALUES ($1, $2, $3, $4, $5, $6, true)
+		INSERT INTO providers (uuid, npi, gnpi, provider_name, specialty, provider_group, priority, due_date, metadata, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, true)
 		ON CONFLICT (npi) DO UPDATE SET 
 			provider_name = EXCLUDED.provider_name,
 			specialty = EXCLUDED.specialty,
 			provider_group = EXCLUDED.provider_group,
+			priority = CASE WHEN $10 THEN EXCLUDED.priority ELSE providers.priority END,
+			due_date = CASE WHEN $11 THEN EXCLUDED.due_date ELSE providers.due_date END,
+			metadata = COALESCE(providers.metadata, '{}'::jsonb) || EXCLUDED.metadata,
 			updated_at = CURRENT_TIMESTAMP
 		RETURNING id
-	`, uuid.New(), npi, nullIfEmpty(gnpi), providerName, nullIfEmpty(specialty), nullIfEmpty(groupName)).Scan(&providerID)
+	`, uuid.New(), npi, nullIfEmpty(gnpi), providerName, nullIfEmpty(specialty), nullIfEmpty(groupName),
+		queue.Priority, queue.DueDate, metadata, queue.PriorityGiven, queue.DueDateGiven).Scan(&providerID)
 	
 	return providerID, err
 }
@@ -243,15 +360,17 @@ func batchInsertAddresses(ctx context.Context, tx pgx.Tx, addresses []AddressRec
 			addr.City,                    // city
 			addr.State,                   // state
 			addr.Zip,                     // zip
+			addr.Key,                     // address_key
 			"US",                         // country
 			addr.IsCorrect,               // is_correct
 			addr.LinkID,                  // link_id
+			addr.Metadata,                // validation_metadata
 		}, nil
 	})
 
 	_, err := tx.CopyFrom(ctx, pgx.Identifier{"provider_addresses"}, 
 		[]string{"uuid", "provider_id", "address_category", "address1", "address2", 
-			"city", "state", "zip", "country", "is_correct", "link_id"}, copySource)
+			"city", "state", "zip", "address_key", "country", "is_correct", "link_id", "validation_metadata"}, copySource)
 	
 	if err != nil {
 		return fmt.Errorf("failed to copy addresses: %w", err)
@@ -274,14 +393,18 @@ func batchInsertPhones(ctx context.Context, tx pgx.Tx, phones []PhoneRecord) err
 			phone.ProviderID,   // provider_id
 			phone.Phone,        // phone
 			phone.PhoneType,    // phone_type
-			nil,                // extension
+			phone.Extension,    // extension
 			phone.IsCorrect,    // is_correct
 			phone.LinkID,       // link_id
+			phone.IsFlagged,    // is_flagged
+			phone.FlagReason,   // flag_reason
+			phone.Metadata,     // validation_metadata
 		}, nil
 	})
 
 	_, err := tx.CopyFrom(ctx, pgx.Identifier{"provider_phones"}, 
-		[]string{"uuid", "provider_id", "phone", "phone_type", "extension", "is_correct", "link_id"}, copySource)
+		[]string{"uuid", "provider_id", "phone", "phone_type", "extension", "is_correct", "link_id",
+			"is_flagged", "flag_reason", "validation_metadata"}, copySource)
 	
 	if err != nil {
 		return fmt.Errorf("failed to copy phones: %w", err)
@@ -299,6 +422,8 @@ func printStatistics(ctx context.Context) {
 		LinkedPairs   int
 		ValidatedAddr int
 		ValidatedPhone int
+		Quarantined   int
+		FlaggedPhones int
 	}
 
 	// Get counts
@@ -313,6 +438,8 @@ func printStatistics(ctx context.Context) {
 	`).Scan(&stats.LinkedPairs)
 	database.QueryRow(ctx, "SELECT COUNT(*) FROM provider_addresses WHERE is_correct IS NOT NULL").Scan(&stats.ValidatedAddr)
 	database.QueryRow(ctx, "SELECT COUNT(*) FROM provider_phones WHERE is_correct IS NOT NULL").Scan(&stats.ValidatedPhone)
+	database.QueryRow(ctx, "SELECT COUNT(*) FROM npi_quarantine").Scan(&stats.Quarantined)
+	database.QueryRow(ctx, "SELECT COUNT(*) FROM provider_phones WHERE is_flagged").Scan(&stats.FlaggedPhones)
 
 	fmt.Printf("\n=== CSV Data Loading Complete ===\n")
 	fmt.Printf("Providers: %d\n", stats.Providers)
@@ -321,6 +448,8 @@ func printStatistics(ctx context.Context) {
 	fmt.Printf("Linked address-phone pairs: %d\n", stats.LinkedPairs)
 	fmt.Printf("Pre-validated addresses: %d\n", stats.ValidatedAddr)
 	fmt.Printf("Pre-validated phones: %d\n", stats.ValidatedPhone)
+	fmt.Printf("Quarantined rows (invalid NPI): %d\n", stats.Quarantined)
+	fmt.Printf("Flagged phones (invalid number): %d\n", stats.FlaggedPhones)
 	fmt.Printf("Ready for validation workflow!\n")
 }
 
@@ -333,18 +462,43 @@ type AddressRecord struct {
 	City            *string
 	State           *string
 	Zip             *string
+	Key             string
 	IsCorrect       *bool
 	LinkID          string
+	Metadata        map[string]interface{}
 }
 
 type PhoneRecord struct {
 	ProviderID int
 	Phone      string
+	Extension  *string
 	PhoneType  string
 	IsCorrect  *bool
 	LinkID     string
+	IsFlagged  bool
+	FlagReason *string
+	Metadata   map[string]interface{}
+}
+
+// queueFields are a row's queue priority and due date, and whether the row
+// set them or they are the import defaults
+type queueFields struct {
+	Priority      int
+	PriorityGiven bool
+	DueDate       *time.Time
+	DueDateGiven  bool
 }
 
+// Queue defaults for rows without priority/due date columns
+var (
+	defaultPriority = 5
+	defaultDueDate  *time.Time
+)
+
+// quarantineInvalidNPIs keeps rows with a bad NPI in npi_quarantine; when
+// false they are only logged
+var quarantineInvalidNPIs = true
+
 // Utility functions for data normalization
 func nullIfEmpty(s string) *string {
 	if s == "" || s == "null" {
@@ -370,51 +524,58 @@ func normalizeAddressCategory(category string) string {
 }
 
 func normalizeState(state string) *string {
-	state = strings.ToUpper(strings.TrimSpace(state))
-	if len(state) == 2 && state != "" {
-		return &state
+	code, err := address.State(state)
+	if err != nil {
+		return nil
 	}
-	return nil
+	return &code
 }
 
 func normalizeZip(zip string) *string {
-	zip = strings.TrimSpace(zip)
-	if zip == "" || zip == "null" {
+	normalized, err := address.Zip(zip)
+	if err != nil {
 		return nil
 	}
-	// Remove any non-digit characters except hyphens
-	normalized := ""
-	for _, char := range zip {
-		if char >= '0' && char <= '9' || char == '-' {
-			normalized += string(char)
-		}
-	}
-	if len(normalized) >= 5 {
-		return &normalized
-	}
-	return nil
+	return &normalized
 }
 
-func normalizePhone(phone string) string {
-	// Remove all non-digit characters
-	normalized := ""
-	for _, char := range phone {
-		if char >= '0' && char <= '9' {
-			normalized += string(char)
+// normalizePhone parses a NANP number for storage in E.164, with any extension
+// split out; impossible numbers are rejected
+func normalizePhone(value string) (phone.Number, error) {
+	return phone.Parse(value)
+}
+
+// storablePhone matches the provider_phones valid_phone constraint
+var storablePhone = regexp.MustCompile(`^\+?[\d\s\-\(\)\.]+$`)
+
+// rawPhone returns an unparseable number in a form provider_phones accepts:
+// as found when the constraint allows it, otherwise just its digits. Values
+// without any digits ("n/a", "unknown") are not numbers at all.
+func rawPhone(value string) (string, bool) {
+	if len(value) <= maxPhoneLength && storablePhone.MatchString(value) {
+		return value, true
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
 		}
+		return -1
+	}, value)
+	if digits == "" {
+		return "", false
 	}
-	
-	// Format as (XXX) XXX-XXXX if it's a 10-digit US number
-	if len(normalized) == 10 {
-		return fmt.Sprintf("(%s) %s-%s", 
-			normalized[0:3], normalized[3:6], normalized[6:10])
-	} else if len(normalized) == 11 && normalized[0] == '1' {
-		// Handle 1-XXX-XXX-XXXX format
-		return fmt.Sprintf("(%s) %s-%s", 
-			normalized[1:4], normalized[4:7], normalized[7:11])
+	if len(digits) > maxPhoneLength {
+		digits = digits[:maxPhoneLength]
 	}
-	
-	return phone // Return original if we can't normalize
+	return digits, true
+}
+
+// maxPhoneLength is the width of provider_phones.phone
+const maxPhoneLength = 20
+
+// validateNPI checks the NPI's length and its Luhn check digit
+func validateNPI(value string) error {
+	return npi.Validate(value)
 }
 
 func parseValidationStatus(status string) *bool {
@@ -428,4 +589,38 @@ func parseValidationStatus(status string) *bool {
 	default:
 		return nil // Unknown status, needs validation
 	}
-}
\ No newline at end of file
+}
+
+func parsePriority(value string) (int, bool) {
+	priority, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || priority < 1 || priority > 10 {
+		return 0, false
+	}
+	return priority, true
+}
+
+// parseLanguages splits a "Spanish; English" list into the lower-case form
+// language skills are stored in
+func parseLanguages(value string) []string {
+	languages := []string{}
+	seen := map[string]bool{}
+	for _, lang := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == ',' || r == '|' }) {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang == "" || lang == "null" || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		languages = append(languages, lang)
+	}
+	return languages
+}
+
+func parseDueDate(value string) (*time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "01/02/2006"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
@@ -6,12 +6,15 @@ import (
 	"log"
 	"os"
 
+	"github.com/user/auth-app/internal/auth"
 	"github.com/user/auth-app/internal/database"
 )
 
 func main() {
-	var action = flag.String("action", "up", "Migration action: up, down, status")
+	var action = flag.String("action", "up", "Migration action: up, down, status, grant-role")
 	var steps = flag.Int("steps", 0, "Number of migration steps for rollback")
+	var email = flag.String("email", "", "Registered user to grant a role to (grant-role)")
+	var role = flag.String("role", auth.RoleAdmin, "Role to grant: agent, supervisor, admin (grant-role)")
 	flag.Parse()
 
 	// Load database configuration
@@ -53,9 +56,19 @@ func main() {
 			log.Fatal("Failed to get migration status:", err)
 		}
 		
+	case "grant-role":
+		// Seeds the first admin, who can then grant roles through the API
+		if *email == "" {
+			log.Fatal("Please specify the user to grant a role to using -email flag")
+		}
+		if err := auth.SetUserRoleByEmail(*email, *role); err != nil {
+			log.Fatalf("Failed to grant %s to %s: %v", *role, *email, err)
+		}
+		fmt.Printf("Granted %s to %s\n", *role, *email)
+
 	default:
 		fmt.Printf("Unknown action: %s\n", *action)
-		fmt.Println("Available actions: up, down, status")
+		fmt.Println("Available actions: up, down, status, grant-role")
 		os.Exit(1)
 	}
 }
\ No newline at end of file
@@ -0,0 +1,142 @@
+// Command zipref regenerates internal/zipref/zip5.csv, the embedded 5-digit
+// ZIP reference, from the GeoNames US postal code dump
+// (https://download.geonames.org/export/zip/US.zip, CC BY 4.0).
+//
+//	go generate ./internal/zipref
+//	go run ./cmd/zipref -source US.zip -out internal/zipref/zip5.csv
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultSource = "https://download.geonames.org/export/zip/US.zip"
+
+// record is one ZIP as written to zip5.csv
+type record struct {
+	zip, state, city, county string
+}
+
+func main() {
+	source := flag.String("source", defaultSource, "GeoNames US.zip or US.txt, as a URL or local path")
+	out := flag.String("out", "zip5.csv", "File to write")
+	flag.Parse()
+
+	data, err := fetch(*source)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *source, err)
+	}
+	if bytes.HasPrefix(data, []byte("PK")) {
+		if data, err = extract(data, "US.txt"); err != nil {
+			log.Fatalf("Failed to unpack %s: %v", *source, err)
+		}
+	}
+
+	records, err := parse(bytes.NewReader(data))
+	if err != nil {
+		log.Fatalf("Failed to parse %s: %v", *source, err)
+	}
+	if err := write(*out, records); err != nil {
+		log.Fatalf("Failed to write %s: %v", *out, err)
+	}
+	fmt.Printf("Wrote %d ZIPs to %s\n", len(records), *out)
+}
+
+func fetch(source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+	client := &http.Client{Timeout: 2 * time.Minute}
+	resp, err := client.Get(source)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func extract(archive []byte, name string) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range reader.File {
+		if file.Name != name {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("archive has no %s", name)
+}
+
+// parse reads GeoNames' tab-separated columns: country, postal code, place
+// name, state name, state code, county name, ... The place name is the
+// primary city USPS uses for the ZIP.
+func parse(r io.Reader) ([]record, error) {
+	byZip := map[string]record{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("line %d: expected at least 6 columns, got %d", line, len(fields))
+		}
+		rec := record{
+			zip:    strings.TrimSpace(fields[1]),
+			city:   strings.TrimSpace(fields[2]),
+			state:  strings.TrimSpace(fields[4]),
+			county: strings.TrimSpace(fields[5]),
+		}
+		if len(rec.zip) != 5 || rec.state == "" {
+			continue
+		}
+		if _, ok := byZip[rec.zip]; !ok {
+			byZip[rec.zip] = rec
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	records := make([]record, 0, len(byZip))
+	for _, rec := range byZip {
+		records = append(records, rec)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].zip < records[j].zip })
+	return records, nil
+}
+
+func write(path string, records []record) error {
+	var buf bytes.Buffer
+	buf.WriteString("# 5-digit ZIP reference generated by cmd/zipref from the GeoNames US postal\n")
+	buf.WriteString("# code dump (https://www.geonames.org, CC BY 4.0). Do not edit by hand.\n")
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"zip", "state", "city", "county"})
+	for _, rec := range records {
+		w.Write([]string{rec.zip, rec.state, rec.city, rec.county})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
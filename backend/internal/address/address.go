@@ -0,0 +1,295 @@
+// Package address standardizes US postal addresses following USPS
+// Publication 28: upper case, abbreviated street suffixes, directionals and
+// secondary unit designators, secondary units on address2, two-letter state
+// codes and ZIP or ZIP+4.
+package address
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrInvalidState = errors.New("state must be a US state or territory")
+	ErrInvalidZip   = errors.New("zip must be 5 digits or ZIP+4")
+)
+
+// Address is a US postal address as the provider tables store it
+type Address struct {
+	Address1 string
+	Address2 string
+	City     string
+	State    string
+	Zip      string
+}
+
+// Standardize returns a in USPS standard form. A state or ZIP that cannot
+// be standardized is returned as typed, along with the error.
+func Standardize(a Address) (Address, error) {
+	var out Address
+	street, unit := Street(a.Address1)
+	secondary := Secondary(a.Address2)
+
+	// "Suite 200" on address1 with the street on address2 is swapped back;
+	// a unit alone stays on address1
+	if street == "" && unit != "" {
+		if secondary == "" {
+			street, unit = unit, ""
+		} else {
+			street, _ = Street(a.Address2)
+			secondary = ""
+		}
+	}
+	out.Address1 = street
+	switch {
+	case unit == "" || unit == secondary:
+		out.Address2 = secondary
+	case secondary == "":
+		out.Address2 = unit
+	default:
+		out.Address2 = unit + " " + secondary
+	}
+	out.City = City(a.City)
+
+	var err error
+	out.State = a.State
+	if strings.TrimSpace(a.State) != "" {
+		if out.State, err = State(a.State); err != nil {
+			out.State = a.State
+		}
+	}
+	out.Zip = a.Zip
+	if strings.TrimSpace(a.Zip) != "" {
+		var zipErr error
+		if out.Zip, zipErr = Zip(a.Zip); zipErr != nil {
+			out.Zip = a.Zip
+			if err == nil {
+				err = zipErr
+			}
+		}
+	}
+	return out, err
+}
+
+// Key is the comparable form of an address used to find duplicates: the
+// standardized address lower-cased with punctuation collapsed and the ZIP cut
+// to five digits. It is stored in provider_addresses.address_key.
+func Key(a Address) string {
+	s, _ := Standardize(a)
+	zip := digitsOnly(s.Zip)
+	if len(zip) > 5 {
+		zip = zip[:5]
+	}
+	raw := strings.Join([]string{s.Address1, s.Address2, s.City, s.State, zip}, " ")
+
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(raw) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+		} else {
+			space = true
+		}
+	}
+	return b.String()
+}
+
+// Street standardizes a delivery address line and splits off any secondary
+// unit ("STE 200", "# 5", "FL 2") found after the street
+func Street(line string) (street, unit string) {
+	tokens := tokenize(line)
+	if len(tokens) == 0 {
+		return "", ""
+	}
+
+	if box, ok := poBox(tokens); ok {
+		return box, ""
+	}
+
+	// A line that is only a unit ("Suite 200") has no street
+	if isUnitStart(tokens, 0) {
+		return "", Secondary(line)
+	}
+
+	// "2nd Floor" at the end of the line
+	if n := len(tokens); n >= 4 && rangedUnits[tokens[n-1]] == "FL" && isOrdinal(tokens[n-2]) {
+		unit = "FL " + strings.TrimRight(tokens[n-2], "STNDRH")
+		tokens = tokens[:n-2]
+	}
+	for i := 2; i < len(tokens); i++ {
+		if isUnitStart(tokens, i) {
+			unit = joinNonEmpty(strings.Join(standardizeSecondary(tokens[i:]), " "), unit)
+			tokens = tokens[:i]
+			break
+		}
+	}
+
+	return strings.Join(standardizeStreet(tokens), " "), unit
+}
+
+// Secondary standardizes an address2 line: unit designators are abbreviated
+// and "2nd Floor" becomes "FL 2"
+func Secondary(line string) string {
+	tokens := tokenize(line)
+	if n := len(tokens); n >= 2 && rangedUnits[tokens[n-1]] == "FL" && isOrdinal(tokens[n-2]) {
+		floor := "FL " + strings.TrimRight(tokens[n-2], "STNDRH")
+		return joinNonEmpty(strings.Join(standardizeSecondary(tokens[:n-2]), " "), floor)
+	}
+	return strings.Join(standardizeSecondary(tokens), " ")
+}
+
+// City upper-cases a city name and collapses its spacing
+func City(city string) string {
+	city = strings.NewReplacer(".", "", ",", " ").Replace(strings.ToUpper(city))
+	return strings.Join(strings.Fields(city), " ")
+}
+
+// State returns the two-letter USPS code for a state code or name
+func State(state string) (string, error) {
+	code, ok := states[City(state)]
+	if !ok {
+		return "", ErrInvalidState
+	}
+	return code, nil
+}
+
+// Zip returns a ZIP as "NNNNN" or "NNNNN-NNNN". ZIPs that lost their leading
+// zero in a spreadsheet (four or eight digits) are padded back.
+func Zip(zip string) (string, error) {
+	for _, r := range zip {
+		if !(r >= '0' && r <= '9') && r != '-' && r != ' ' {
+			return "", ErrInvalidZip
+		}
+	}
+	digits := digitsOnly(zip)
+	if len(digits) == 4 || len(digits) == 8 {
+		digits = "0" + digits
+	}
+	switch len(digits) {
+	case 5:
+		return digits, nil
+	case 9:
+		// An all-zero +4 carries no information
+		if digits[5:] == "0000" {
+			return digits[:5], nil
+		}
+		return digits[:5] + "-" + digits[5:], nil
+	}
+	return "", ErrInvalidZip
+}
+
+// tokenize upper-cases a line, drops periods and commas, and splits a
+// leading "#" from its number
+func tokenize(line string) []string {
+	line = strings.NewReplacer(".", "", ",", " ", "#", " # ").Replace(strings.ToUpper(line))
+	return strings.Fields(line)
+}
+
+// standardizeStreet abbreviates the pre-directional, suffix and
+// post-directional of a street line. Words that are the street name itself
+// ("123 NORTH ST", "100 AVENUE OF THE AMERICAS") are left alone.
+func standardizeStreet(tokens []string) []string {
+	out := append([]string(nil), tokens...)
+	start := 0
+	if len(out) > 0 && hasDigit(out[0]) {
+		start = 1
+	}
+	end := len(out)
+	if end-1 > start {
+		if dir, ok := directionals[out[end-1]]; ok {
+			out[end-1] = dir
+			end--
+		}
+	}
+	if end-1 > start {
+		if suffix, ok := streetSuffixes[out[end-1]]; ok {
+			out[end-1] = suffix
+			end--
+		}
+	}
+	if end-start >= 2 {
+		if dir, ok := directionals[out[start]]; ok {
+			out[start] = dir
+		}
+	}
+	return out
+}
+
+// standardizeSecondary abbreviates unit designators in a secondary address
+func standardizeSecondary(tokens []string) []string {
+	out := make([]string, len(tokens))
+	for i, token := range tokens {
+		if unit, ok := rangedUnits[token]; ok && (i+1 < len(tokens) || token == "#") {
+			out[i] = unit
+		} else if unit, ok := unrangedUnits[token]; ok {
+			out[i] = unit
+		} else {
+			out[i] = token
+		}
+	}
+	return out
+}
+
+// isUnitStart reports whether a secondary unit begins at tokens[i]: a
+// designator followed by a unit number, or a designator that takes none at
+// the end of the line
+func isUnitStart(tokens []string, i int) bool {
+	if _, ok := rangedUnits[tokens[i]]; ok {
+		return i+1 < len(tokens) && isUnitNumber(tokens[i+1])
+	}
+	_, ok := unrangedUnits[tokens[i]]
+	return ok && i == len(tokens)-1 && i > 0
+}
+
+// isUnitNumber accepts "200", "B", "2A", "200-B"; "KEY LARGO" is a street name
+func isUnitNumber(token string) bool {
+	return hasDigit(token) || len(token) == 1
+}
+
+func isOrdinal(token string) bool {
+	for _, suffix := range []string{"ST", "ND", "RD", "TH"} {
+		if strings.HasSuffix(token, suffix) && len(token) > 2 && digitsOnly(token) == token[:len(token)-2] {
+			return true
+		}
+	}
+	return false
+}
+
+// poBox recognizes "PO BOX 12", "P O BOX 12" and "POST OFFICE BOX 12"
+func poBox(tokens []string) (string, bool) {
+	joined := strings.Join(tokens, " ")
+	for _, prefix := range []string{"PO BOX ", "P O BOX ", "POST OFFICE BOX ", "POB "} {
+		if strings.HasPrefix(joined, prefix) {
+			return "PO BOX " + strings.TrimPrefix(joined, prefix), true
+		}
+	}
+	return "", false
+}
+
+func hasDigit(s string) bool {
+	return strings.ContainsAny(s, "0123456789")
+}
+
+func digitsOnly(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func joinNonEmpty(parts ...string) string {
+	var kept []string
+	for _, part := range parts {
+		if part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, " ")
+}
@@ -0,0 +1,172 @@
+package address
+
+import "testing"
+
+func TestStreet(t *testing.T) {
+	tests := []struct {
+		line, street, unit string
+	}{
+		// Suffixes
+		{"123 Main Street", "123 MAIN ST", ""},
+		{"500 Oak Avenue", "500 OAK AVE", ""},
+		{"42 Elm Boulevard", "42 ELM BLVD", ""},
+		{"9 Sunset Drive", "9 SUNSET DR", ""},
+		{"77 Harbor Parkway", "77 HARBOR PKWY", ""},
+		{"1 Market Sq.", "1 MARKET SQ", ""},
+
+		// Directionals before and after the street name
+		{"123 North Main Street", "123 N MAIN ST", ""},
+		{"400 Pennsylvania Avenue Northwest", "400 PENNSYLVANIA AVE NW", ""},
+		{"15 S. Wacker Dr.", "15 S WACKER DR", ""},
+		// Words that are the street name are left alone
+		{"123 North Street", "123 NORTH ST", ""},
+		{"100 Avenue of the Americas", "100 AVENUE OF THE AMERICAS", ""},
+
+		// Secondary units after the street
+		{"123 Main St Suite 200", "123 MAIN ST", "STE 200"},
+		{"123 Main St, Ste. 200", "123 MAIN ST", "STE 200"},
+		{"123 Main St #5", "123 MAIN ST", "# 5"},
+		{"123 Main St Apartment 4B", "123 MAIN ST", "APT 4B"},
+		{"123 Main St Building C", "123 MAIN ST", "BLDG C"},
+		{"123 Main St 2nd Floor", "123 MAIN ST", "FL 2"},
+		{"123 Main St Penthouse", "123 MAIN ST", "PH"},
+
+		// A line that is only a unit
+		{"Suite 200", "", "STE 200"},
+
+		{"P.O. Box 12", "PO BOX 12", ""},
+		{"Post Office Box 12", "PO BOX 12", ""},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			street, unit := Street(tt.line)
+			if street != tt.street || unit != tt.unit {
+				t.Errorf("Street(%q) = %q, %q; want %q, %q", tt.line, street, unit, tt.street, tt.unit)
+			}
+		})
+	}
+}
+
+func TestSecondary(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"Suite 200", "STE 200"},
+		{"Apt. 4B", "APT 4B"},
+		{"Room 12", "RM 12"},
+		{"Unit 3", "UNIT 3"},
+		{"3rd Floor", "FL 3"},
+		{"Lobby", "LBBY"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Secondary(tt.line); got != tt.want {
+			t.Errorf("Secondary(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestStandardize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   Address
+		want Address
+		err  error
+	}{
+		{
+			name: "unit moved to address2",
+			in:   Address{Address1: "123 north main street suite 200", City: "springfield", State: "il", Zip: "62701"},
+			want: Address{Address1: "123 N MAIN ST", Address2: "STE 200", City: "SPRINGFIELD", State: "IL", Zip: "62701"},
+		},
+		{
+			name: "unit on both lines",
+			in:   Address{Address1: "123 Main St Bldg C", Address2: "Suite 200", City: "Springfield", State: "IL", Zip: "62701"},
+			want: Address{Address1: "123 MAIN ST", Address2: "BLDG C STE 200", City: "SPRINGFIELD", State: "IL", Zip: "62701"},
+		},
+		{
+			name: "unit and street swapped",
+			in:   Address{Address1: "Suite 200", Address2: "123 Main St", City: "Springfield", State: "IL", Zip: "62701"},
+			want: Address{Address1: "123 MAIN ST", Address2: "STE 200", City: "SPRINGFIELD", State: "IL", Zip: "62701"},
+		},
+		{
+			name: "state name and ZIP+4",
+			in:   Address{Address1: "1 Main St", City: "St. Louis", State: "Missouri", Zip: "63101 1234"},
+			want: Address{Address1: "1 MAIN ST", City: "ST LOUIS", State: "MO", Zip: "63101-1234"},
+		},
+		{
+			name: "leading zero restored",
+			in:   Address{Address1: "1 Main St", City: "Boston", State: "MA", Zip: "2108"},
+			want: Address{Address1: "1 MAIN ST", City: "BOSTON", State: "MA", Zip: "02108"},
+		},
+		{
+			name: "unknown state kept as typed",
+			in:   Address{Address1: "1 Main St", City: "Springfield", State: "Illinoise", Zip: "62701"},
+			want: Address{Address1: "1 MAIN ST", City: "SPRINGFIELD", State: "Illinoise", Zip: "62701"},
+			err:  ErrInvalidState,
+		},
+		{
+			name: "malformed ZIP kept as typed",
+			in:   Address{Address1: "1 Main St", City: "Springfield", State: "IL", Zip: "627"},
+			want: Address{Address1: "1 MAIN ST", City: "SPRINGFIELD", State: "IL", Zip: "627"},
+			err:  ErrInvalidZip,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Standardize(tt.in)
+			if err != tt.err {
+				t.Errorf("Standardize() error = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Standardize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		in, want string
+		err      error
+	}{
+		{"62701", "62701", nil},
+		{"62701-1234", "62701-1234", nil},
+		{"627011234", "62701-1234", nil},
+		{"62701-0000", "62701", nil},
+		{"2108", "02108", nil},
+		{"21081234", "02108-1234", nil},
+		{"627", "", ErrInvalidZip},
+		{"62701-12", "", ErrInvalidZip},
+		{"6270A", "", ErrInvalidZip},
+	}
+
+	for _, tt := range tests {
+		got, err := Zip(tt.in)
+		if got != tt.want || err != tt.err {
+			t.Errorf("Zip(%q) = %q, %v; want %q, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	same := []Address{
+		{Address1: "123 North Main Street", Address2: "Suite 200", City: "Springfield", State: "IL", Zip: "62701"},
+		{Address1: "123 N. Main St., Ste 200", City: "SPRINGFIELD", State: "Illinois", Zip: "62701-1234"},
+		{Address1: "123 N MAIN ST", Address2: "STE 200", City: "springfield", State: "il", Zip: "62701"},
+	}
+	want := "123 n main st ste 200 springfield il 62701"
+	for _, a := range same {
+		if got := Key(a); got != want {
+			t.Errorf("Key(%+v) = %q, want %q", a, got, want)
+		}
+	}
+
+	other := Address{Address1: "123 N Main St", Address2: "Ste 300", City: "Springfield", State: "IL", Zip: "62701"}
+	if Key(other) == want {
+		t.Errorf("Key(%+v) matches a different suite", other)
+	}
+}
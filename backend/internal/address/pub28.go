@@ -0,0 +1,212 @@
+package address
+
+// USPS Publication 28 abbreviations. Each table maps the primary name, its
+// common misspellings and the standard abbreviation itself to the standard
+// abbreviation, so lookups also recognize already-standardized input.
+
+// streetSuffixes is Appendix C1, street suffixes
+var streetSuffixes = buildTable(map[string][]string{
+	"ALY":  {"ALLEY", "ALLEE", "ALLY"},
+	"ANX":  {"ANNEX", "ANEX", "ANNX"},
+	"ARC":  {"ARCADE"},
+	"AVE":  {"AVENUE", "AV", "AVEN", "AVENU", "AVN", "AVNUE"},
+	"BYU":  {"BAYOU", "BAYOO"},
+	"BCH":  {"BEACH"},
+	"BND":  {"BEND"},
+	"BLF":  {"BLUFF", "BLUF"},
+	"BTM":  {"BOTTOM", "BOT", "BOTTM"},
+	"BLVD": {"BOULEVARD", "BOUL", "BOULV"},
+	"BR":   {"BRANCH", "BRNCH"},
+	"BRG":  {"BRIDGE", "BRDGE"},
+	"BRK":  {"BROOK"},
+	"BYP":  {"BYPASS", "BYPA", "BYPAS", "BYPS"},
+	"CP":   {"CAMP", "CMP"},
+	"CYN":  {"CANYON", "CANYN", "CNYN"},
+	"CPE":  {"CAPE"},
+	"CSWY": {"CAUSEWAY", "CAUSWA"},
+	"CTR":  {"CENTER", "CEN", "CENT", "CENTR", "CENTRE", "CNTER", "CNTR"},
+	"CIR":  {"CIRCLE", "CIRC", "CIRCL", "CRCL", "CRCLE"},
+	"CLF":  {"CLIFF"},
+	"CLB":  {"CLUB"},
+	"CMN":  {"COMMON"},
+	"COR":  {"CORNER"},
+	"CORS": {"CORNERS"},
+	"CRSE": {"COURSE"},
+	"CT":   {"COURT"},
+	"CTS":  {"COURTS"},
+	"CV":   {"COVE"},
+	"CRK":  {"CREEK"},
+	"CRES": {"CRESCENT", "CRSENT", "CRSNT"},
+	"XING": {"CROSSING", "CRSSNG"},
+	"XRD":  {"CROSSROAD"},
+	"CURV": {"CURVE"},
+	"DL":   {"DALE"},
+	"DM":   {"DAM"},
+	"DV":   {"DIVIDE", "DIV", "DVD"},
+	"DR":   {"DRIVE", "DRIV", "DRV"},
+	"EST":  {"ESTATE"},
+	"ESTS": {"ESTATES"},
+	"EXPY": {"EXPRESSWAY", "EXP", "EXPR", "EXPRESS", "EXPW"},
+	"EXT":  {"EXTENSION", "EXTN", "EXTNSN"},
+	"FLS":  {"FALLS"},
+	"FRY":  {"FERRY", "FRRY"},
+	"FLD":  {"FIELD"},
+	"FLDS": {"FIELDS"},
+	"FLT":  {"FLAT"},
+	"FRST": {"FOREST", "FORESTS"},
+	"FRG":  {"FORGE", "FORG"},
+	"FRK":  {"FORK"},
+	"FT":   {"FORT", "FRT"},
+	"FWY":  {"FREEWAY", "FREEWY", "FRWAY", "FRWY"},
+	"GDN":  {"GARDEN", "GARDN", "GRDEN", "GRDN"},
+	"GDNS": {"GARDENS"},
+	"GTWY": {"GATEWAY", "GATEWY", "GATWAY", "GTWAY"},
+	"GLN":  {"GLEN"},
+	"GRN":  {"GREEN"},
+	"GRV":  {"GROVE", "GROV"},
+	"HBR":  {"HARBOR", "HARB", "HARBR", "HRBOR"},
+	"HVN":  {"HAVEN"},
+	"HTS":  {"HEIGHTS", "HT"},
+	"HWY":  {"HIGHWAY", "HIGHWY", "HIWAY", "HIWY", "HWAY"},
+	"HL":   {"HILL"},
+	"HLS":  {"HILLS"},
+	"HOLW": {"HOLLOW", "HLLW", "HOLLOWS", "HOLWS"},
+	"IS":   {"ISLAND", "ISLND"},
+	"JCT":  {"JUNCTION", "JCTION", "JCTN", "JUNCTN", "JUNCTON"},
+	"KNL":  {"KNOLL", "KNOL"},
+	"LK":   {"LAKE"},
+	"LKS":  {"LAKES"},
+	"LNDG": {"LANDING", "LNDNG"},
+	"LN":   {"LANE"},
+	"LOOP": {"LOOPS"},
+	"MALL": {},
+	"MNR":  {"MANOR"},
+	"MDW":  {"MEADOW"},
+	"MDWS": {"MEADOWS", "MEDOWS"},
+	"ML":   {"MILL"},
+	"MSN":  {"MISSION", "MISSN", "MSSN"},
+	"MTWY": {"MOTORWAY"},
+	"MT":   {"MOUNT", "MNT"},
+	"MTN":  {"MOUNTAIN", "MNTAIN", "MNTN", "MOUNTIN", "MTIN"},
+	"OVAL": {"OVL"},
+	"OPAS": {"OVERPASS"},
+	"PARK": {"PRK"},
+	"PKWY": {"PARKWAY", "PARKWY", "PKWAY", "PKY"},
+	"PASS": {},
+	"PATH": {"PATHS"},
+	"PIKE": {"PIKES"},
+	"PNE":  {"PINE"},
+	"PL":   {"PLACE"},
+	"PLN":  {"PLAIN"},
+	"PLNS": {"PLAINS"},
+	"PLZ":  {"PLAZA", "PLZA"},
+	"PT":   {"POINT"},
+	"PTS":  {"POINTS"},
+	"PRT":  {"PORT"},
+	"PR":   {"PRAIRIE", "PRR"},
+	"RNCH": {"RANCH", "RANCHES", "RNCHS"},
+	"RDG":  {"RIDGE", "RDGE"},
+	"RIV":  {"RIVER", "RVR", "RIVR"},
+	"RD":   {"ROAD"},
+	"RTE":  {"ROUTE"},
+	"ROW":  {},
+	"RUN":  {},
+	"SHR":  {"SHORE", "SHOAR"},
+	"SKWY": {"SKYWAY"},
+	"SPG":  {"SPRING", "SPNG", "SPRNG"},
+	"SPGS": {"SPRINGS"},
+	"SQ":   {"SQUARE", "SQR", "SQRE", "SQU"},
+	"STA":  {"STATION", "STATN", "STN"},
+	"ST":   {"STREET", "STRT", "STR"},
+	"SMT":  {"SUMMIT", "SUMIT", "SUMITT"},
+	"TER":  {"TERRACE", "TERR"},
+	"TRCE": {"TRACE", "TRACES"},
+	"TRL":  {"TRAIL", "TRAILS", "TRLS"},
+	"TUNL": {"TUNNEL", "TUNEL", "TUNLS", "TUNNELS", "TUNNL"},
+	"TPKE": {"TURNPIKE", "TRNPK", "TURNPK"},
+	"UN":   {"UNION"},
+	"VLY":  {"VALLEY", "VALLY", "VLLY"},
+	"VW":   {"VIEW"},
+	"VLG":  {"VILLAGE", "VILL", "VILLAG", "VILLG", "VILLIAGE"},
+	"VIS":  {"VISTA", "VIST", "VST", "VSTA"},
+	"WALK": {},
+	"WAY":  {"WY"},
+	"WL":   {"WELL"},
+	"WLS":  {"WELLS"},
+})
+
+// directionals is section 233, directionals
+var directionals = buildTable(map[string][]string{
+	"N":  {"NORTH"},
+	"S":  {"SOUTH"},
+	"E":  {"EAST"},
+	"W":  {"WEST"},
+	"NE": {"NORTHEAST"},
+	"NW": {"NORTHWEST"},
+	"SE": {"SOUTHEAST"},
+	"SW": {"SOUTHWEST"},
+})
+
+// rangedUnits is Appendix C2, secondary unit designators that require a number
+var rangedUnits = buildTable(map[string][]string{
+	"APT":  {"APARTMENT", "APPT"},
+	"BLDG": {"BUILDING", "BLD"},
+	"DEPT": {"DEPARTMENT"},
+	"FL":   {"FLOOR", "FLR"},
+	"HNGR": {"HANGAR"},
+	"KEY":  {},
+	"LOT":  {},
+	"PIER": {},
+	"RM":   {"ROOM"},
+	"SLIP": {},
+	"SPC":  {"SPACE"},
+	"STOP": {},
+	"STE":  {"SUITE", "SUIT", "STES"},
+	"TRLR": {"TRAILER"},
+	"UNIT": {},
+	"#":    {"NO", "NUM", "NUMBER"},
+})
+
+// unrangedUnits is Appendix C2, secondary unit designators used alone
+var unrangedUnits = buildTable(map[string][]string{
+	"BSMT": {"BASEMENT"},
+	"FRNT": {"FRONT"},
+	"LBBY": {"LOBBY"},
+	"LOWR": {"LOWER"},
+	"OFC":  {"OFFICE"},
+	"PH":   {"PENTHOUSE"},
+	"REAR": {},
+	"SIDE": {},
+	"UPPR": {"UPPER"},
+})
+
+// states maps state and territory names to their two-letter USPS codes
+var states = buildTable(map[string][]string{
+	"AL": {"ALABAMA"}, "AK": {"ALASKA"}, "AZ": {"ARIZONA"}, "AR": {"ARKANSAS"},
+	"CA": {"CALIFORNIA"}, "CO": {"COLORADO"}, "CT": {"CONNECTICUT"}, "DE": {"DELAWARE"},
+	"DC": {"DISTRICT OF COLUMBIA"}, "FL": {"FLORIDA"}, "GA": {"GEORGIA"}, "HI": {"HAWAII"},
+	"ID": {"IDAHO"}, "IL": {"ILLINOIS"}, "IN": {"INDIANA"}, "IA": {"IOWA"},
+	"KS": {"KANSAS"}, "KY": {"KENTUCKY"}, "LA": {"LOUISIANA"}, "ME": {"MAINE"},
+	"MD": {"MARYLAND"}, "MA": {"MASSACHUSETTS"}, "MI": {"MICHIGAN"}, "MN": {"MINNESOTA"},
+	"MS": {"MISSISSIPPI"}, "MO": {"MISSOURI"}, "MT": {"MONTANA"}, "NE": {"NEBRASKA"},
+	"NV": {"NEVADA"}, "NH": {"NEW HAMPSHIRE"}, "NJ": {"NEW JERSEY"}, "NM": {"NEW MEXICO"},
+	"NY": {"NEW YORK"}, "NC": {"NORTH CAROLINA"}, "ND": {"NORTH DAKOTA"}, "OH": {"OHIO"},
+	"OK": {"OKLAHOMA"}, "OR": {"OREGON"}, "PA": {"PENNSYLVANIA"}, "RI": {"RHODE ISLAND"},
+	"SC": {"SOUTH CAROLINA"}, "SD": {"SOUTH DAKOTA"}, "TN": {"TENNESSEE"}, "TX": {"TEXAS"},
+	"UT": {"UTAH"}, "VT": {"VERMONT"}, "VA": {"VIRGINIA"}, "WA": {"WASHINGTON"},
+	"WV": {"WEST VIRGINIA"}, "WI": {"WISCONSIN"}, "WY": {"WYOMING"},
+	"AS": {"AMERICAN SAMOA"}, "GU": {"GUAM"}, "MP": {"NORTHERN MARIANA ISLANDS"},
+	"PR": {"PUERTO RICO"}, "VI": {"VIRGIN ISLANDS", "US VIRGIN ISLANDS"},
+	"AA": {}, "AE": {}, "AP": {},
+})
+
+func buildTable(entries map[string][]string) map[string]string {
+	table := make(map[string]string)
+	for abbr, names := range entries {
+		table[abbr] = abbr
+		for _, name := range names {
+			table[name] = abbr
+		}
+	}
+	return table
+}
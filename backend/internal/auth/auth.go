@@ -16,6 +16,13 @@ import (
 
 var jwtSecret = []byte("your-secret-key-change-in-production")
 
+// User roles, stored in users.role
+const (
+	RoleAgent      = "agent"
+	RoleSupervisor = "supervisor"
+	RoleAdmin      = "admin"
+)
+
 func RegisterUser(email, password string) (*models.User, error) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -28,11 +35,11 @@ func RegisterUser(email, password string) (*models.User, error) {
 	query := `
 		INSERT INTO users (uuid, email, password, is_active) 
 		VALUES ($1, $2, $3, true) 
-		RETURNING id, uuid, email, is_active, created_at, updated_at
+		RETURNING id, uuid, email, role, is_active, created_at, updated_at
 	`
 	
 	err = database.QueryRow(ctx, query, uuid.New(), email, string(hashedPassword)).Scan(
-		&user.ID, &user.UUID, &user.Email, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.ID, &user.UUID, &user.Email, &user.Role, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user: %w", err)
@@ -46,7 +53,7 @@ func LoginUser(email, password string) (*models.User, string, error) {
 	var user models.User
 	
 	query := `
-		SELECT id, uuid, email, password, first_name, last_name, is_active,
+		SELECT id, uuid, email, password, first_name, last_name, role, is_active,
 		       last_login_at, metadata, created_at, updated_at, created_by, updated_by
 		FROM users 
 		WHERE email = $1 AND is_active = true
@@ -54,7 +61,7 @@ func LoginUser(email, password string) (*models.User, string, error) {
 	
 	err := database.QueryRow(ctx, query, email).Scan(
 		&user.ID, &user.UUID, &user.Email, &user.Password,
-		&user.FirstName, &user.LastName, &user.IsActive,
+		&user.FirstName, &user.LastName, &user.Role, &user.IsActive,
 		&user.LastLoginAt, &user.Metadata, &user.CreatedAt, &user.UpdatedAt,
 		&user.CreatedBy, &user.UpdatedBy,
 	)
@@ -139,7 +146,7 @@ func GetUserByID(userID int) (*models.User, error) {
 	var user models.User
 	
 	query := `
-		SELECT id, uuid, email, first_name, last_name, is_active,
+		SELECT id, uuid, email, first_name, last_name, role, is_active,
 		       last_login_at, metadata, created_at, updated_at, created_by, updated_by
 		FROM users 
 		WHERE id = $1 AND is_active = true
@@ -147,7 +154,7 @@ func GetUserByID(userID int) (*models.User, error) {
 	
 	err := database.QueryRow(ctx, query, userID).Scan(
 		&user.ID, &user.UUID, &user.Email, &user.FirstName, &user.LastName,
-		&user.IsActive, &user.LastLoginAt, &user.Metadata,
+		&user.Role, &user.IsActive, &user.LastLoginAt, &user.Metadata,
 		&user.CreatedAt, &user.UpdatedAt, &user.CreatedBy, &user.UpdatedBy,
 	)
 	
@@ -159,4 +166,50 @@ func GetUserByID(userID int) (*models.User, error) {
 	}
 	
 	return &user, nil
-}
\ No newline at end of file
+}
+
+// IsSupervisor reports whether the user may perform supervisor operations
+func IsSupervisor(user *models.User) bool {
+	return user.Role == RoleSupervisor || user.Role == RoleAdmin
+}
+
+// IsAdmin reports whether the user may manage other users' roles
+func IsAdmin(user *models.User) bool {
+	return user.Role == RoleAdmin
+}
+
+var (
+	ErrInvalidRole  = errors.New("invalid role")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// SetUserRole grants a role to the user with the given ID. updatedBy is
+// nil when the role is granted from the command line.
+func SetUserRole(userID int, role string, updatedBy *int) error {
+	return setUserRole(`id = $1`, userID, role, updatedBy)
+}
+
+// SetUserRoleByEmail grants a role by email, for bootstrapping the first admin
+func SetUserRoleByEmail(email string, role string) error {
+	return setUserRole(`email = $1`, email, role, nil)
+}
+
+func setUserRole(where string, key interface{}, role string, updatedBy *int) error {
+	switch role {
+	case RoleAgent, RoleSupervisor, RoleAdmin:
+	default:
+		return ErrInvalidRole
+	}
+
+	ctx := context.Background()
+	result, err := database.DB.Exec(ctx, `
+		UPDATE users SET role = $2, updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE `+where, key, role, updatedBy)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Package calendar decides which days provider offices are open: weekdays
+// that are not US federal holidays or admin-defined closures.
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Holiday is a day offices are closed
+type Holiday struct {
+	Date   time.Time `json:"date"`
+	Name   string    `json:"name"`
+	State  string    `json:"state,omitempty"` // empty applies to every state
+	Source string    `json:"source"`          // federal or closure
+}
+
+// Holiday sources
+const (
+	SourceFederal = "federal"
+	SourceClosure = "closure"
+)
+
+// Calendar answers business day questions for a set of states. A closure
+// tied to a state applies when any of the calendar's states match it.
+type Calendar struct {
+	states   map[string]bool
+	closures map[string]Holiday
+	loc      *time.Location // office time zone; nil reads times in their own location
+}
+
+// New builds a calendar from admin closures, keeping those that apply to states
+func New(closures []Holiday, states ...string) *Calendar {
+	c := &Calendar{
+		states:   make(map[string]bool),
+		closures: make(map[string]Holiday),
+	}
+	for _, state := range states {
+		if state != "" {
+			c.states[state] = true
+		}
+	}
+	for _, closure := range closures {
+		if closure.State == "" || c.states[closure.State] {
+			c.closures[closure.Date.Format(dateLayout)] = closure
+		}
+	}
+	return c
+}
+
+// In returns a copy of the calendar that reads dates and office hours in loc
+func (c *Calendar) In(loc *time.Location) *Calendar {
+	cal := *c
+	cal.loc = loc
+	return &cal
+}
+
+// Local converts t to the office time zone when one is set
+func (c *Calendar) Local(t time.Time) time.Time {
+	if c.loc == nil {
+		return t
+	}
+	return t.In(c.loc)
+}
+
+// Holiday returns the holiday or closure falling on t's local date, if any
+func (c *Calendar) Holiday(t time.Time) (Holiday, bool) {
+	t = c.Local(t)
+	key := t.Format(dateLayout)
+	if closure, ok := c.closures[key]; ok {
+		return closure, true
+	}
+	// Observed dates can spill into the previous year (New Year's Day on a Saturday)
+	for _, year := range []int{t.Year(), t.Year() + 1} {
+		for _, h := range FederalHolidays(year) {
+			if h.Date.Format(dateLayout) == key {
+				return h, true
+			}
+		}
+	}
+	return Holiday{}, false
+}
+
+// IsBusinessDay reports whether offices are open on t's local date
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	t = c.Local(t)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	_, closed := c.Holiday(t)
+	return !closed
+}
+
+// AddBusinessDays returns the same local time of day on the nth business day after t
+func (c *Calendar) AddBusinessDays(t time.Time, n int) time.Time {
+	t = c.Local(t)
+	for n > 0 {
+		t = t.AddDate(0, 0, 1)
+		if c.IsBusinessDay(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// AddBusinessHours advances t by d, counting only office hours on business
+// days in the calendar's time zone. Office hours run from open to close, both
+// measured from local midnight; close at or before open counts whole days.
+func (c *Calendar) AddBusinessHours(t time.Time, d time.Duration, open, close time.Duration) time.Time {
+	if close <= open {
+		open, close = 0, 24*time.Hour
+	}
+	t = c.Local(t)
+	for d > 0 {
+		year, month, day := t.Date()
+		// Built from the wall clock so DST changes do not shift office hours
+		start := time.Date(year, month, day, 0, int(open/time.Minute), 0, 0, t.Location())
+		end := time.Date(year, month, day, 0, int(close/time.Minute), 0, 0, t.Location())
+		next := StartOfDay(t).AddDate(0, 0, 1)
+		if !c.IsBusinessDay(t) || !t.Before(end) {
+			t = next
+			continue
+		}
+		if t.Before(start) {
+			t = start
+		}
+		if left := end.Sub(t); left < d {
+			d -= left
+			t = next
+			continue
+		}
+		return t.Add(d)
+	}
+	return t
+}
+
+// NextBusinessDay returns the start of the first business day on or after t
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	t = StartOfDay(c.Local(t))
+	for !c.IsBusinessDay(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// Holidays lists federal holidays and applicable closures observed in year
+func (c *Calendar) Holidays(year int, loc *time.Location) []Holiday {
+	var holidays []Holiday
+	for _, y := range []int{year, year + 1} {
+		for _, h := range FederalHolidays(y) {
+			if h.Date.Year() == year {
+				h.Date = time.Date(h.Date.Year(), h.Date.Month(), h.Date.Day(), 0, 0, 0, 0, loc)
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	for _, closure := range c.closures {
+		if closure.Date.Year() == year {
+			holidays = append(holidays, closure)
+		}
+	}
+
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// StartOfDay truncates t to midnight in its own location
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// FederalHolidays returns the observed US federal holidays for year (5 U.S.C. 6103).
+// A holiday on Saturday is observed the Friday before, on Sunday the Monday after.
+func FederalHolidays(year int) []Holiday {
+	fixed := func(month time.Month, day int, name string) Holiday {
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		switch date.Weekday() {
+		case time.Saturday:
+			date = date.AddDate(0, 0, -1)
+		case time.Sunday:
+			date = date.AddDate(0, 0, 1)
+		}
+		return Holiday{Date: date, Name: name, Source: SourceFederal}
+	}
+	nth := func(month time.Month, weekday time.Weekday, n int, name string) Holiday {
+		date := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		for date.Weekday() != weekday {
+			date = date.AddDate(0, 0, 1)
+		}
+		return Holiday{Date: date.AddDate(0, 0, 7*(n-1)), Name: name, Source: SourceFederal}
+	}
+	last := func(month time.Month, weekday time.Weekday, name string) Holiday {
+		date := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		for date.Weekday() != weekday {
+			date = date.AddDate(0, 0, -1)
+		}
+		return Holiday{Date: date, Name: name, Source: SourceFederal}
+	}
+
+	holidays := []Holiday{
+		fixed(time.January, 1, "New Year's Day"),
+		nth(time.January, time.Monday, 3, "Birthday of Martin Luther King, Jr."),
+		nth(time.February, time.Monday, 3, "Washington's Birthday"),
+		last(time.May, time.Monday, "Memorial Day"),
+	}
+	if year >= 2021 {
+		holidays = append(holidays, fixed(time.June, 19, "Juneteenth National Independence Day"))
+	}
+	holidays = append(holidays,
+		fixed(time.July, 4, "Independence Day"),
+		nth(time.September, time.Monday, 1, "Labor Day"),
+		nth(time.October, time.Monday, 2, "Columbus Day"),
+		fixed(time.November, 11, "Veterans Day"),
+		nth(time.November, time.Thursday, 4, "Thanksgiving Day"),
+		fixed(time.December, 25, "Christmas Day"),
+	)
+	return holidays
+}
@@ -0,0 +1,215 @@
+package calendar
+
+import (
+	"fmt"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestFederalHolidaysObservedDates(t *testing.T) {
+	tests := []struct {
+		name string
+		year int
+		want string
+	}{
+		// Saturday holidays move to Friday, Sunday holidays to Monday
+		{"Independence Day", 2026, "2026-07-03"},
+		{"Independence Day", 2021, "2021-07-05"},
+		{"Independence Day", 2025, "2025-07-04"},
+		{"Christmas Day", 2022, "2022-12-26"},
+		{"Veterans Day", 2023, "2023-11-10"},
+		{"Juneteenth National Independence Day", 2027, "2027-06-18"},
+		// New Year's Day on a Saturday is observed the year before
+		{"New Year's Day", 2022, "2021-12-31"},
+		{"New Year's Day", 2026, "2026-01-01"},
+
+		// Fourth Thursday of November
+		{"Thanksgiving Day", 2024, "2024-11-28"},
+		{"Thanksgiving Day", 2026, "2026-11-26"},
+		{"Birthday of Martin Luther King, Jr.", 2026, "2026-01-19"},
+		{"Memorial Day", 2026, "2026-05-25"},
+		{"Labor Day", 2026, "2026-09-07"},
+		{"Columbus Day", 2026, "2026-10-12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %d", tt.name, tt.year), func(t *testing.T) {
+			for _, h := range FederalHolidays(tt.year) {
+				if h.Name == tt.name {
+					if got := h.Date.Format(dateLayout); got != tt.want {
+						t.Errorf("%s %d observed %s, want %s", tt.name, tt.year, got, tt.want)
+					}
+					return
+				}
+			}
+			t.Errorf("%s missing from %d", tt.name, tt.year)
+		})
+	}
+}
+
+func TestFederalHolidaysJuneteenth(t *testing.T) {
+	for _, tt := range []struct {
+		year int
+		want int
+	}{{2020, 10}, {2021, 11}, {2026, 11}} {
+		if got := len(FederalHolidays(tt.year)); got != tt.want {
+			t.Errorf("FederalHolidays(%d) has %d holidays, want %d", tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarHolidays(t *testing.T) {
+	c := New(nil)
+	// The observed New Year's Day for 2022 falls in 2021
+	if h, ok := c.Holiday(date("2021-12-31")); !ok || h.Name != "New Year's Day" {
+		t.Errorf("Holiday(2021-12-31) = %+v, %v; want New Year's Day", h, ok)
+	}
+	if got := len(c.Holidays(2021, time.UTC)); got != 12 {
+		t.Errorf("Holidays(2021) has %d entries, want 12", got)
+	}
+	if got := len(c.Holidays(2022, time.UTC)); got != 10 {
+		t.Errorf("Holidays(2022) has %d entries, want 10", got)
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	closures := []Holiday{
+		{Date: date("2026-12-24"), Name: "Christmas Eve", Source: SourceClosure},
+	}
+
+	tests := []struct {
+		day    string
+		states []string
+		want   bool
+	}{
+		{"2026-07-02", nil, true},
+		{"2026-07-03", nil, false}, // Independence Day observed
+		{"2026-07-04", nil, false}, // Saturday
+		{"2026-11-26", nil, false}, // Thanksgiving
+		{"2026-11-27", nil, true},
+		{"2026-12-24", nil, false}, // nationwide closure
+		{"2026-12-24", []string{"IL"}, false},
+	}
+
+	for _, tt := range tests {
+		c := New(closures, tt.states...)
+		if got := c.IsBusinessDay(date(tt.day)); got != tt.want {
+			t.Errorf("IsBusinessDay(%s) for %v = %v, want %v", tt.day, tt.states, got, tt.want)
+		}
+	}
+}
+
+func TestStateClosure(t *testing.T) {
+	closures := []Holiday{{Date: date("2026-03-10"), Name: "Storm", State: "CO", Source: SourceClosure}}
+	if !New(closures, "IL").IsBusinessDay(date("2026-03-10")) {
+		t.Error("a Colorado closure closed an Illinois calendar")
+	}
+	if New(closures, "IL", "CO").IsBusinessDay(date("2026-03-10")) {
+		t.Error("a Colorado closure did not close a calendar covering Colorado")
+	}
+}
+
+func TestIsBusinessDayInOfficeTimeZone(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(nil).In(chicago)
+
+	// Monday 00:30 UTC is still Sunday evening in Chicago
+	if c.IsBusinessDay(time.Date(2026, 7, 13, 0, 30, 0, 0, time.UTC)) {
+		t.Error("Sunday 19:30 in Chicago counted as a business day")
+	}
+	// Saturday 03:00 UTC is Friday evening in Chicago
+	if !c.IsBusinessDay(time.Date(2026, 7, 11, 3, 0, 0, 0, time.UTC)) {
+		t.Error("Friday 22:00 in Chicago not counted as a business day")
+	}
+	// Independence Day is observed Friday 2026-07-03 in Chicago, not UTC
+	if _, ok := c.Holiday(time.Date(2026, 7, 4, 2, 0, 0, 0, time.UTC)); !ok {
+		t.Error("Friday 21:00 on 2026-07-03 in Chicago not recognised as a holiday")
+	}
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	c := New(nil)
+	tests := []struct {
+		from string
+		n    int
+		want string
+	}{
+		{"2026-07-01", 1, "2026-07-02"},
+		// Over the observed Independence Day and the weekend
+		{"2026-07-02", 1, "2026-07-06"},
+		// Over Thanksgiving
+		{"2026-11-25", 2, "2026-11-30"},
+		// Christmas Day is a Friday
+		{"2026-12-24", 1, "2026-12-28"},
+	}
+
+	for _, tt := range tests {
+		if got := c.AddBusinessDays(date(tt.from), tt.n).Format(dateLayout); got != tt.want {
+			t.Errorf("AddBusinessDays(%s, %d) = %s, want %s", tt.from, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	c := New(nil)
+	got := c.NextBusinessDay(date("2026-07-03").Add(10 * time.Hour))
+	if want := date("2026-07-06"); !got.Equal(want) {
+		t.Errorf("NextBusinessDay(2026-07-03 10:00) = %s, want %s", got, want)
+	}
+}
+
+func TestAddBusinessHours(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(loc *time.Location, day string, hour, min int) time.Time {
+		d := date(day)
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, min, 0, 0, loc)
+	}
+	const open, close = 8 * time.Hour, 17 * time.Hour
+
+	tests := []struct {
+		name string
+		from time.Time
+		d    time.Duration
+		want time.Time
+	}{
+		{"within one day", at(chicago, "2026-07-01", 9, 0), 3 * time.Hour, at(chicago, "2026-07-01", 12, 0)},
+		{"before opening", at(chicago, "2026-07-01", 6, 0), 3 * time.Hour, at(chicago, "2026-07-01", 11, 0)},
+		// 17:30 in Chicago; the evening does not count
+		{"after closing, given in UTC", at(time.UTC, "2026-07-01", 22, 30), time.Hour, at(chicago, "2026-07-02", 9, 0)},
+		{"ends at closing", at(chicago, "2026-07-01", 15, 0), 2 * time.Hour, at(chicago, "2026-07-01", 17, 0)},
+		// Over the observed Independence Day and the weekend
+		{"over a holiday weekend", at(chicago, "2026-07-02", 16, 0), 2 * time.Hour, at(chicago, "2026-07-06", 9, 0)},
+		// Clocks go forward on Sunday 2026-03-08; office hours stay 08:00-17:00
+		{"over daylight saving", at(chicago, "2026-03-06", 16, 0), 2 * time.Hour, at(chicago, "2026-03-09", 9, 0)},
+	}
+
+	c := New(nil).In(chicago)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.AddBusinessHours(tt.from, tt.d, open, close); !got.Equal(tt.want) {
+				t.Errorf("AddBusinessHours(%s, %s) = %s, want %s", tt.from, tt.d, got, tt.want)
+			}
+		})
+	}
+
+	// Without office hours whole business days count
+	from := at(time.UTC, "2026-11-27", 20, 0)
+	if got, want := New(nil).AddBusinessHours(from, 8*time.Hour, 0, 0), at(time.UTC, "2026-11-30", 4, 0); !got.Equal(want) {
+		t.Errorf("AddBusinessHours(%s, 8h) without office hours = %s, want %s", from, got, want)
+	}
+}
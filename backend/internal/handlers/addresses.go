@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/address"
+	"github.com/user/auth-app/internal/providers"
+	"github.com/user/auth-app/internal/zipref"
+)
+
+// StandardizeAddresses keys every stored address by its USPS standard form
+func StandardizeAddresses(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	result, err := providers.StandardizeAddresses(userID)
+	if err != nil {
+		log.Printf("StandardizeAddresses: Failed to standardize addresses: %v", err)
+		http.Error(w, "Failed to standardize addresses", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func ListDuplicateAddresses(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	groups, err := providers.ListDuplicateAddresses(limit)
+	if err != nil {
+		log.Printf("ListDuplicateAddresses: Failed to list duplicates: %v", err)
+		http.Error(w, "Failed to list duplicate addresses", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+// LookupZip returns the state, city and time zone the reference has for a
+// ZIP, so agents can fill in a correction from the ZIP alone
+func LookupZip(w http.ResponseWriter, r *http.Request) {
+	zip := mux.Vars(r)["zip"]
+	if _, err := address.Zip(zip); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	entry, ok := zipref.Lookup(zip)
+	if !ok {
+		http.Error(w, "ZIP code not found", http.StatusNotFound)
+		return
+	}
+
+	timezone, err := providers.LocationTimezone(entry.State, entry.Zip)
+	if err != nil {
+		log.Printf("LookupZip: Failed to get time zone for %s: %v", zip, err)
+		http.Error(w, "Failed to look up ZIP code", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		zipref.Entry
+		Timezone string `json:"timezone,omitempty"`
+	}{entry, timezone})
+}
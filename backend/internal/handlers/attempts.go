@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func GetCallAttemptStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	status, err := providers.GetCallAttemptStatus(sessionID, userID)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		default:
+			log.Printf("GetCallAttemptStatus: Failed to load attempts for session %d: %v", sessionID, err)
+			http.Error(w, "Failed to get call attempt status", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func ListCallAttemptPolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := providers.ListCallAttemptPolicies()
+	if err != nil {
+		log.Printf("ListCallAttemptPolicies: Failed to list policies: %v", err)
+		http.Error(w, "Failed to list call attempt policies", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(policies)
+}
+
+func SetCallAttemptPolicy(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+
+	var policy models.CallAttemptPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	policy.Campaign = vars["campaign"]
+
+	err := providers.SetCallAttemptPolicy(userID, policy)
+	if err != nil {
+		if err == providers.ErrInvalidCallPolicy {
+			http.Error(w, "Policy needs max_attempts between 1 and 10, no more steps than attempts, non-negative spacing and valid outcomes", http.StatusBadRequest)
+			return
+		}
+		log.Printf("SetCallAttemptPolicy: Failed to save policy %q: %v", policy.Campaign, err)
+		http.Error(w, "Failed to save call attempt policy", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func DeleteCallAttemptPolicy(w http.ResponseWriter, r *http.Request) {
+	campaign := mux.Vars(r)["campaign"]
+
+	err := providers.DeleteCallAttemptPolicy(campaign)
+	if err != nil {
+		switch err {
+		case providers.ErrInvalidCallPolicy:
+			http.Error(w, "The default policy cannot be deleted", http.StatusBadRequest)
+		case providers.ErrCallPolicyNotFound:
+			http.Error(w, "Call attempt policy not found", http.StatusNotFound)
+		default:
+			log.Printf("DeleteCallAttemptPolicy: Failed to delete policy %q: %v", campaign, err)
+			http.Error(w, "Failed to delete call attempt policy", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
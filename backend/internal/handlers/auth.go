@@ -2,8 +2,11 @@ package handlers
 
 import (
 	"encoding/json"
+	"log"
 	"net/http"
+	"strconv"
 
+	"github.com/gorilla/mux"
 	"github.com/user/auth-app/internal/auth"
 	"github.com/user/auth-app/internal/models"
 )
@@ -83,4 +86,38 @@ func GetUser(w http.ResponseWriter, r *http.Request) {
 	
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(user)
-}
\ No newline at end of file
+}
+
+// SetUserRole grants a user the agent, supervisor or admin role
+func SetUserRole(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UserRoleUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = auth.SetUserRole(userID, req.Role, &adminID)
+	if err != nil {
+		switch err {
+		case auth.ErrInvalidRole:
+			http.Error(w, "Role must be agent, supervisor or admin", http.StatusBadRequest)
+		case auth.ErrUserNotFound:
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			log.Printf("SetUserRole: Failed to set role for user %d: %v", userID, err)
+			http.Error(w, "Failed to set role", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func GetHolidays(w http.ResponseWriter, r *http.Request) {
+	year := time.Now().Year()
+	if value := r.URL.Query().Get("year"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 2000 || parsed > 2100 {
+			http.Error(w, "year must be between 2000 and 2100", http.StatusBadRequest)
+			return
+		}
+		year = parsed
+	}
+	state := strings.ToUpper(r.URL.Query().Get("state"))
+	if state != "" && len(state) != 2 {
+		http.Error(w, "state must be a two-letter code", http.StatusBadRequest)
+		return
+	}
+
+	cal, err := providers.GetBusinessCalendar(state)
+	if err != nil {
+		log.Printf("GetHolidays: Failed to load calendar: %v", err)
+		http.Error(w, "Failed to load business calendar", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cal.Holidays(year, time.UTC))
+}
+
+func GetNextBusinessDay(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	from := time.Now()
+	if value := query.Get("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			parsed, err = time.Parse("2006-01-02", value)
+		}
+		if err != nil {
+			http.Error(w, "from must be RFC 3339 or YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	}
+
+	days := 1
+	if value := query.Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 || parsed > 365 {
+			http.Error(w, "days must be between 0 and 365", http.StatusBadRequest)
+			return
+		}
+		days = parsed
+	}
+
+	result, err := providers.NextBusinessDay(from, days, query.Get("state"))
+	if err != nil {
+		if err == providers.ErrInvalidClosure {
+			http.Error(w, "state must be a two-letter code", http.StatusBadRequest)
+			return
+		}
+		log.Printf("GetNextBusinessDay: Failed to compute business day: %v", err)
+		http.Error(w, "Failed to compute business day", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func ListClosures(w http.ResponseWriter, r *http.Request) {
+	closures, err := providers.ListClosures()
+	if err != nil {
+		log.Printf("ListClosures: Failed to list closures: %v", err)
+		http.Error(w, "Failed to list closures", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(closures)
+}
+
+func AddClosure(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	var input models.BusinessClosureInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	closure, err := providers.AddClosure(userID, input)
+	if err != nil {
+		if err == providers.ErrInvalidClosure {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("AddClosure: Failed to add closure: %v", err)
+		http.Error(w, "Failed to add closure", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(closure)
+}
+
+func DeleteClosure(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	closureID, err := strconv.Atoi(vars["closureId"])
+	if err != nil {
+		http.Error(w, "Invalid closure ID", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.DeleteClosure(closureID)
+	if err != nil {
+		if err == providers.ErrClosureNotFound {
+			http.Error(w, "Closure not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("DeleteClosure: Failed to delete closure %d: %v", closureID, err)
+		http.Error(w, "Failed to delete closure", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
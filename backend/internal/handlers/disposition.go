@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func MarkUnreachable(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UnreachableRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = providers.MarkUnreachable(sessionID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found or not in progress", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrNotUnreachable:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			log.Printf("MarkUnreachable: Failed to dispose session %d: %v", sessionID, err)
+			http.Error(w, "Failed to mark provider unreachable", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func ClearDisposition(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	providerID, err := strconv.Atoi(vars["providerId"])
+	if err != nil {
+		http.Error(w, "Invalid provider ID", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.ClearDisposition(providerID, userID)
+	if err != nil {
+		if err == providers.ErrProviderNotFound {
+			http.Error(w, "Provider not found or has no disposition", http.StatusNotFound)
+			return
+		}
+		log.Printf("ClearDisposition: Failed to clear provider %d: %v", providerID, err)
+		http.Error(w, "Failed to clear disposition", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// ExportOutcomes streams every provider's validation outcome as CSV
+func ExportOutcomes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="provider_outcomes.csv"`)
+
+	out := csv.NewWriter(w)
+	out.Write([]string{"provider_id", "npi", "provider_name", "outcome", "disposition_reason", "disposition_at", "last_completed_at"})
+
+	formatTime := func(t models.NullTime) string {
+		if !t.Valid {
+			return ""
+		}
+		return t.Time.Format(time.RFC3339)
+	}
+
+	err := providers.StreamProviderOutcomes(r.Context(), func(o models.ProviderOutcome) error {
+		return out.Write([]string{
+			strconv.Itoa(o.ProviderID),
+			o.NPI,
+			o.ProviderName,
+			o.Outcome,
+			o.DispositionReason.String,
+			formatTime(o.DispositionAt),
+			formatTime(o.LastCompletedAt),
+		})
+	})
+	if err != nil {
+		// Headers are already sent; the truncated file is the best we can do
+		log.Printf("ExportOutcomes: Failed to export outcomes: %v", err)
+	}
+	out.Flush()
+}
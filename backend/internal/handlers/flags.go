@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func FlagPhone(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	var req models.FlagPhoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	flag, err := providers.FlagPhone(userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrInvalidPhone:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case providers.ErrInvalidFlag:
+			http.Error(w, "flag_type must be invalid, disconnected, wrong_number, fax, do_not_call or spam, and severity 1-5", http.StatusBadRequest)
+		default:
+			log.Printf("FlagPhone: Failed to flag phone: %v", err)
+			http.Error(w, "Failed to flag phone", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(flag)
+}
+
+func ListFlaggedPhones(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.FlaggedPhoneFilter{
+		Query:    query.Get("q"),
+		FlagType: query.Get("type"),
+	}
+
+	var err error
+	if value := query.Get("min_severity"); value != "" {
+		if filter.MinSeverity, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "min_severity must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("include_resolved"); value != "" {
+		if filter.IncludeResolved, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "include_resolved must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil || filter.Limit < 1 {
+			http.Error(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "offset must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	flags, err := providers.ListFlaggedPhones(filter)
+	if err != nil {
+		if err == providers.ErrInvalidFlag {
+			http.Error(w, "Invalid filter: check type, min_severity (0-5), limit (1-500) and offset", http.StatusBadRequest)
+			return
+		}
+		log.Printf("ListFlaggedPhones: Failed to list flags: %v", err)
+		http.Error(w, "Failed to list flagged phones", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(flags)
+}
+
+func ResolveFlag(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	flagID, err := strconv.Atoi(vars["flagId"])
+	if err != nil {
+		http.Error(w, "Invalid flag ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ResolveFlagRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = providers.ResolveFlag(flagID, userID, req)
+	if err != nil {
+		if err == providers.ErrFlagNotFound {
+			http.Error(w, "Flag not found or already resolved", http.StatusNotFound)
+			return
+		}
+		log.Printf("ResolveFlag: Failed to resolve flag %d: %v", flagID, err)
+		http.Error(w, "Failed to resolve flag", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
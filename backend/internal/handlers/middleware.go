@@ -31,4 +31,42 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		ctx := context.WithValue(r.Context(), "user_id", userID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	}
-}
\ No newline at end of file
+}
+
+// SupervisorMiddleware authenticates the request and restricts it to supervisors and admins
+func SupervisorMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Context().Value("user_id").(int)
+
+		user, err := auth.GetUserByID(userID)
+		if err != nil {
+			http.Error(w, "Invalid user", http.StatusUnauthorized)
+			return
+		}
+		if !auth.IsSupervisor(user) {
+			http.Error(w, "Supervisor access required", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AdminMiddleware authenticates the request and restricts it to admins
+func AdminMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return AuthMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.Context().Value("user_id").(int)
+
+		user, err := auth.GetUserByID(userID)
+		if err != nil {
+			http.Error(w, "Invalid user", http.StatusUnauthorized)
+			return
+		}
+		if !auth.IsAdmin(user) {
+			http.Error(w, "Admin access required", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
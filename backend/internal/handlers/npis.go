@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/user/auth-app/internal/providers"
+)
+
+// ListInvalidNPIs reports stored providers whose NPI fails the check digit
+func ListInvalidNPIs(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	invalid, err := providers.ListInvalidNPIs(limit)
+	if err != nil {
+		log.Printf("ListInvalidNPIs: Failed to list invalid NPIs: %v", err)
+		http.Error(w, "Failed to list invalid NPIs", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(invalid)
+}
+
+// ListQuarantinedNPIs returns import rows the loader set aside for their NPI
+func ListQuarantinedNPIs(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	quarantined, err := providers.ListQuarantinedNPIs(limit)
+	if err != nil {
+		log.Printf("ListQuarantinedNPIs: Failed to list quarantined rows: %v", err)
+		http.Error(w, "Failed to list quarantined rows", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(quarantined)
+}
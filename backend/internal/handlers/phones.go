@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/user/auth-app/internal/providers"
+)
+
+// ListAreaCodeMismatches reports phones whose area code does not match any of
+// the provider's address states
+func ListAreaCodeMismatches(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 1000 {
+			http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	mismatches, err := providers.ListAreaCodeMismatches(limit)
+	if err != nil {
+		log.Printf("ListAreaCodeMismatches: Failed to list mismatches: %v", err)
+		http.Error(w, "Failed to list area code mismatches", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(mismatches)
+}
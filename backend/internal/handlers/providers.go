@@ -2,12 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/address"
 	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/npi"
 	"github.com/user/auth-app/internal/providers"
 )
 
@@ -56,18 +62,34 @@ func UpdateValidation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = providers.UpdateValidation(sessionID, userID, req)
+	warnings, err := providers.UpdateValidation(sessionID, userID, req)
 	if err != nil {
 		if err == providers.ErrSessionLocked {
 			http.Error(w, "Session is locked by another user", http.StatusConflict)
 			return
 		}
+		if err == providers.ErrInvalidPhone {
+			http.Error(w, "corrected_phone: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err == address.ErrInvalidState || err == address.ErrInvalidZip {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err == providers.ErrZipMismatch {
+			http.Error(w, "The ZIP code is not in the address state; look it up at /api/reference/zips/{zip}", http.StatusBadRequest)
+			return
+		}
 		log.Printf("UpdateValidation: Failed to update validation for session %d: %v", sessionID, err)
 		http.Error(w, "Failed to update validation", http.StatusInternalServerError)
 		return
 	}
+	response := map[string]interface{}{"status": "success"}
+	if len(warnings) > 0 {
+		response["warnings"] = warnings
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	json.NewEncoder(w).Encode(response)
 }
 
 func RecordCallAttempt(w http.ResponseWriter, r *http.Request) {
@@ -85,7 +107,7 @@ func RecordCallAttempt(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = providers.RecordCallAttempt(sessionID, userID, req.AttemptNumber)
+	status, err := providers.RecordCallAttempt(sessionID, userID, req)
 	if err != nil {
 		if err == providers.ErrSessionLocked {
 			http.Error(w, "Session is locked by another user", http.StatusConflict)
@@ -95,12 +117,33 @@ func RecordCallAttempt(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Invalid call attempt", http.StatusBadRequest)
 			return
 		}
+		if err == providers.ErrInvalidCallOutcome {
+			http.Error(w, "phone_id and an outcome of successful, no_answer, busy, disconnected or invalid are required", http.StatusBadRequest)
+			return
+		}
+		if err == providers.ErrOutcomeNotAllowed {
+			http.Error(w, "Outcome is not allowed for this attempt", http.StatusBadRequest)
+			return
+		}
+		if err == providers.ErrAttemptTooSoon && status != nil && status.NextAttemptAllowedAt != nil {
+			http.Error(w, fmt.Sprintf("Call attempt %d is not allowed until %s",
+				req.AttemptNumber, status.NextAttemptAllowedAt.Format(time.RFC3339)), http.StatusConflict)
+			return
+		}
+		if err == providers.ErrOutsideCallingWindow {
+			http.Error(w, "The provider's office is outside calling hours", http.StatusConflict)
+			return
+		}
+		if err == providers.ErrPhoneNotFound {
+			http.Error(w, "Phone not found for this provider", http.StatusNotFound)
+			return
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+	json.NewEncoder(w).Encode(status)
 }
 
 func CompleteValidation(w http.ResponseWriter, r *http.Request) {
@@ -150,6 +193,81 @@ func GetValidationPreview(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(preview)
 }
 
+func PreviewPhonePropagation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+	phoneID, err := strconv.Atoi(vars["phoneId"])
+	if err != nil {
+		http.Error(w, "Invalid phone ID", http.StatusBadRequest)
+		return
+	}
+
+	preview, err := providers.PreviewPhonePropagation(sessionID, userID, phoneID)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrPhoneNotFound:
+			http.Error(w, "Phone not found for this provider", http.StatusNotFound)
+		default:
+			log.Printf("PreviewPhonePropagation: Failed to preview phone %d: %v", phoneID, err)
+			http.Error(w, "Failed to preview propagation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
+func PreviewAddressPropagation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+	addressID, err := strconv.Atoi(vars["addressId"])
+	if err != nil {
+		http.Error(w, "Invalid address ID", http.StatusBadRequest)
+		return
+	}
+	withinGroup := false
+	if value := r.URL.Query().Get("within_group"); value != "" {
+		if withinGroup, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "within_group must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	preview, err := providers.PreviewAddressPropagation(sessionID, userID, addressID, withinGroup)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrAddressNotFound:
+			http.Error(w, "Address not found for this provider", http.StatusNotFound)
+		default:
+			log.Printf("PreviewAddressPropagation: Failed to preview address %d: %v", addressID, err)
+			http.Error(w, "Failed to preview propagation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(preview)
+}
+
 func GetProviderStats(w http.ResponseWriter, r *http.Request) {
 	userID := r.Context().Value("user_id").(int)
 
@@ -161,4 +279,94 @@ func GetProviderStats(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
-}
\ No newline at end of file
+}
+// SearchProviders looks providers up by text and filters. Only active
+// providers are returned unless active=false or active=all.
+func SearchProviders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := models.ProviderSearchFilter{
+		Query:            query.Get("q"),
+		NPIPrefix:        query.Get("npi"),
+		Specialty:        query.Get("specialty"),
+		State:            query.Get("state"),
+		Group:            query.Get("group"),
+		ValidationStatus: query.Get("status"),
+	}
+
+	var err error
+	active := true
+	switch value := query.Get("active"); value {
+	case "":
+		filter.Active = &active
+	case "all":
+	default:
+		if active, err = strconv.ParseBool(value); err != nil {
+			http.Error(w, "active must be true, false or all", http.StatusBadRequest)
+			return
+		}
+		filter.Active = &active
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil || filter.Limit < 1 {
+			http.Error(w, "limit must be between 1 and 100", http.StatusBadRequest)
+			return
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			http.Error(w, "offset must be a number", http.StatusBadRequest)
+			return
+		}
+	}
+
+	page, err := providers.SearchProviders(filter)
+	if err != nil {
+		if err == providers.ErrInvalidSearch {
+			http.Error(w, "Invalid filter: check npi (digits), state, status, limit (1-100) and offset", http.StatusBadRequest)
+			return
+		}
+		if err == npi.ErrCheckDigit {
+			http.Error(w, "npi: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("SearchProviders: Failed to search providers: %v", err)
+		http.Error(w, "Failed to search providers", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// GetProvider returns a provider by NPI or UUID with its addresses, phones and
+// validation history
+func GetProvider(w http.ResponseWriter, r *http.Request) {
+	ref := mux.Vars(r)["provider"]
+
+	var detail *models.ProviderDetail
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		detail, err = providers.GetProviderByUUID(id)
+	} else if len(ref) == 10 && strings.Trim(ref, "0123456789") == "" {
+		detail, err = providers.GetProviderByNPI(ref)
+	} else {
+		http.Error(w, "Provider must be a 10-digit NPI or a UUID", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		if err == providers.ErrProviderNotFound {
+			http.Error(w, "Provider not found", http.StatusNotFound)
+			return
+		}
+		if err == npi.ErrFormat || err == npi.ErrCheckDigit {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		log.Printf("GetProvider: Failed to get provider %s: %v", ref, err)
+		http.Error(w, "Failed to get provider", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(detail)
+}
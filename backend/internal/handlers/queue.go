@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func SetProviderPriority(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	providerID, err := strconv.Atoi(vars["providerId"])
+	if err != nil {
+		http.Error(w, "Invalid provider ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ProviderPriorityUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.SetProviderPriority(providerID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrInvalidPriority:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case providers.ErrProviderNotFound:
+			http.Error(w, "Provider not found", http.StatusNotFound)
+		default:
+			log.Printf("SetProviderPriority: Failed to update provider %d: %v", providerID, err)
+			http.Error(w, "Failed to update priority", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func GetQueuePreview(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 500 {
+			http.Error(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	items, err := providers.GetQueuePreview(limit)
+	if err != nil {
+		log.Printf("GetQueuePreview: Failed to load queue: %v", err)
+		http.Error(w, "Failed to load queue", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func HeartbeatSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	lock, err := providers.HeartbeatSession(sessionID, userID)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrSessionNotActive:
+			http.Error(w, "Session lock has been lost", http.StatusConflict)
+		default:
+			log.Printf("HeartbeatSession: Failed to renew lock for session %d: %v", sessionID, err)
+			http.Error(w, "Failed to renew session lock", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lock)
+}
+
+func ReleaseSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReleaseSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.ReleaseSession(sessionID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrInvalidReleaseReason:
+			http.Error(w, "A valid reason_code is required", http.StatusBadRequest)
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrSessionNotActive:
+			http.Error(w, "Session is no longer active", http.StatusConflict)
+		default:
+			log.Printf("ReleaseSession: Failed to release session %d: %v", sessionID, err)
+			http.Error(w, "Failed to release session", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func HoldSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.HoldSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.HoldSession(sessionID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrInvalidCallbackTime, providers.ErrCallbackNotBusinessDay:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrSessionNotActive:
+			http.Error(w, "Session is no longer active", http.StatusConflict)
+		default:
+			log.Printf("HoldSession: Failed to hold session %d: %v", sessionID, err)
+			http.Error(w, "Failed to put session on hold", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func ListCallbacks(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+
+	callbacks, err := providers.ListCallbacks(userID)
+	if err != nil {
+		log.Printf("ListCallbacks: Failed to list callbacks for user %d: %v", userID, err)
+		http.Error(w, "Failed to list callbacks", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(callbacks)
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func GetUserSkills(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	skills, err := providers.GetUserSkills(userID)
+	if err != nil {
+		log.Printf("GetUserSkills: Failed to get skills for user %d: %v", userID, err)
+		http.Error(w, "Failed to get skills", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(skills)
+}
+
+func SetUserSkills(w http.ResponseWriter, r *http.Request) {
+	adminID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	userID, err := strconv.Atoi(vars["userId"])
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.UserSkillsUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.SetUserSkills(userID, adminID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrInvalidSkill:
+			http.Error(w, "Skills must be a state code, language or specialty", http.StatusBadRequest)
+		case providers.ErrUserNotFound:
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			log.Printf("SetUserSkills: Failed to set skills for user %d: %v", userID, err)
+			http.Error(w, "Failed to set skills", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
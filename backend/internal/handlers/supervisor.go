@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+)
+
+func ListActiveSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := providers.ListActiveSessions()
+	if err != nil {
+		log.Printf("ListActiveSessions: Failed to list sessions: %v", err)
+		http.Error(w, "Failed to list active sessions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func ForceReleaseSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	// The body is optional; a supervisor may release without notes
+	var req models.ForceReleaseRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	err = providers.ForceReleaseSession(sessionID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionNotActive:
+			http.Error(w, "Session is no longer active", http.StatusConflict)
+		default:
+			log.Printf("ForceReleaseSession: Failed to release session %d: %v", sessionID, err)
+			http.Error(w, "Failed to release session", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func ReassignSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.ReassignSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.ReassignSession(sessionID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrHandoffNoteRequired:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrUserNotFound:
+			http.Error(w, "Target agent not found or inactive", http.StatusNotFound)
+		case providers.ErrSessionNotActive:
+			http.Error(w, "Session is no longer active", http.StatusConflict)
+		case providers.ErrAgentBusy:
+			http.Error(w, "Target agent already has an active session", http.StatusConflict)
+		default:
+			log.Printf("ReassignSession: Failed to reassign session %d: %v", sessionID, err)
+			http.Error(w, "Failed to reassign session", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func ListAddressPropagations(w http.ResponseWriter, r *http.Request) {
+	limit := 50
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > 500 {
+			http.Error(w, "limit must be between 1 and 500", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	propagations, err := providers.ListAddressPropagations(limit)
+	if err != nil {
+		log.Printf("ListAddressPropagations: Failed to list propagations: %v", err)
+		http.Error(w, "Failed to list propagations", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(propagations)
+}
+
+func UndoAddressPropagation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	propagationID, err := strconv.Atoi(vars["propagationId"])
+	if err != nil {
+		http.Error(w, "Invalid propagation ID", http.StatusBadRequest)
+		return
+	}
+
+	restored, err := providers.UndoAddressPropagation(propagationID, userID)
+	if err != nil {
+		switch err {
+		case providers.ErrPropagationNotFound:
+			http.Error(w, "Propagation not found", http.StatusNotFound)
+		case providers.ErrPropagationUndone:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			log.Printf("UndoAddressPropagation: Failed to undo propagation %d: %v", propagationID, err)
+			http.Error(w, "Failed to undo propagation", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{"status": "success", "restored": restored})
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/providers"
+	"github.com/user/auth-app/internal/telephony"
+)
+
+func StartCall(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	var req models.DialRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	call, err := providers.StartCall(sessionID, userID, req)
+	if err != nil {
+		switch err {
+		case providers.ErrTelephonyDisabled:
+			http.Error(w, "Click-to-dial is not configured", http.StatusServiceUnavailable)
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found or not in progress", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		case providers.ErrPhoneNotFound:
+			http.Error(w, "Phone not found for this provider", http.StatusNotFound)
+		case providers.ErrInvalidCallAttempt:
+			http.Error(w, "Invalid call attempt", http.StatusBadRequest)
+		case providers.ErrAttemptTooSoon:
+			http.Error(w, "Call attempt is not allowed yet", http.StatusConflict)
+		case providers.ErrOutsideCallingWindow:
+			http.Error(w, "The provider's office is outside calling hours", http.StatusConflict)
+		case providers.ErrCallInProgress:
+			http.Error(w, err.Error(), http.StatusConflict)
+		case providers.ErrDialFailed:
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		default:
+			log.Printf("StartCall: Failed to dial for session %d: %v", sessionID, err)
+			http.Error(w, "Failed to start call", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(call)
+}
+
+func HangupCall(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	callID, err := uuid.Parse(vars["callId"])
+	if err != nil {
+		http.Error(w, "Invalid call ID", http.StatusBadRequest)
+		return
+	}
+
+	err = providers.HangupCall(callID, userID)
+	if err != nil {
+		switch err {
+		case providers.ErrTelephonyDisabled:
+			http.Error(w, "Click-to-dial is not configured", http.StatusServiceUnavailable)
+		case providers.ErrCallNotFound, telephony.ErrUnknownCall:
+			http.Error(w, "Call not found or already ended", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		default:
+			log.Printf("HangupCall: Failed to hang up call %s: %v", callID, err)
+			http.Error(w, "Failed to hang up call", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+func ListSessionCalls(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value("user_id").(int)
+	vars := mux.Vars(r)
+	sessionID, err := strconv.Atoi(vars["sessionId"])
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	calls, err := providers.ListSessionCalls(sessionID, userID)
+	if err != nil {
+		switch err {
+		case providers.ErrSessionNotFound:
+			http.Error(w, "Session not found", http.StatusNotFound)
+		case providers.ErrSessionLocked:
+			http.Error(w, "Session is locked by another user", http.StatusConflict)
+		default:
+			log.Printf("ListSessionCalls: Failed to list calls for session %d: %v", sessionID, err)
+			http.Error(w, "Failed to list calls", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(calls)
+}
+
+// TelephonyWebhook receives call events from the carrier. It is not behind
+// AuthMiddleware; the dialer authenticates the request instead.
+func TelephonyWebhook(w http.ResponseWriter, r *http.Request) {
+	event, err := providers.ParseCallEvent(r)
+	if err != nil {
+		switch err {
+		case providers.ErrTelephonyDisabled:
+			http.Error(w, "Telephony is not configured", http.StatusNotFound)
+		case telephony.ErrInvalidSignature:
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		default:
+			http.Error(w, "Invalid call event", http.StatusBadRequest)
+		}
+		return
+	}
+
+	err = providers.HandleCallEvent(event)
+	if err != nil {
+		if err == providers.ErrCallNotFound {
+			http.Error(w, "Call not found", http.StatusNotFound)
+			return
+		}
+		log.Printf("TelephonyWebhook: Failed to apply %s event for call %s: %v", event.Type, event.CallID, err)
+		http.Error(w, "Failed to process call event", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// BusinessClosure is an admin-defined day offices are closed
+type BusinessClosure struct {
+	ID        int        `json:"id"`
+	Date      string     `json:"date"`  // YYYY-MM-DD
+	State     NullString `json:"state"` // null closes every state
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy NullInt64  `json:"created_by,omitempty"`
+}
+
+type BusinessClosureInput struct {
+	Date  string `json:"date"`
+	State string `json:"state,omitempty"`
+	Name  string `json:"name"`
+}
+
+// BusinessDayResult answers a next-business-day query
+type BusinessDayResult struct {
+	From         time.Time `json:"from"`
+	Days         int       `json:"days"`
+	State        string    `json:"state,omitempty"`
+	Date         time.Time `json:"date"`
+	SkippedDates []string  `json:"skipped_dates,omitempty"` // closed weekdays passed over
+}
@@ -7,21 +7,24 @@ import (
 )
 
 type Provider struct {
-	ID               int                    `json:"id"`
-	UUID             uuid.UUID              `json:"uuid"`
-	NPI              string                 `json:"npi"`
-	GNPI             NullString             `json:"gnpi"`
-	ProviderName     string                 `json:"provider_name"`
-	Specialty        NullString             `json:"specialty"`
-	ProviderGroup    NullString             `json:"provider_group"`
-	LicenseNumbers   []string               `json:"license_numbers,omitempty"`
-	Credentials      []string               `json:"credentials,omitempty"`
-	Metadata         map[string]interface{} `json:"metadata,omitempty"`
-	IsActive         bool                   `json:"is_active"`
-	CreatedAt        time.Time              `json:"created_at"`
-	UpdatedAt        time.Time              `json:"updated_at"`
-	CreatedBy        NullInt64              `json:"created_by,omitempty"`
-	UpdatedBy        NullInt64              `json:"updated_by,omitempty"`
+	ID                int                    `json:"id"`
+	UUID              uuid.UUID              `json:"uuid"`
+	NPI               string                 `json:"npi"`
+	GNPI              NullString             `json:"gnpi"`
+	ProviderName      string                 `json:"provider_name"`
+	Specialty         NullString             `json:"specialty"`
+	ProviderGroup     NullString             `json:"provider_group"`
+	LicenseNumbers    []string               `json:"license_numbers,omitempty"`
+	Credentials       []string               `json:"credentials,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	IsActive          bool                   `json:"is_active"`
+	Priority          int                    `json:"priority"` // 1 (lowest) to 10 (most urgent)
+	DueDate           NullTime               `json:"due_date"`
+	EffectivePriority int                    `json:"effective_priority"` // priority after queue aging
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+	CreatedBy         NullInt64              `json:"created_by,omitempty"`
+	UpdatedBy         NullInt64              `json:"updated_by,omitempty"`
 }
 
 type ProviderAddress struct {
@@ -57,16 +60,22 @@ type ProviderPhone struct {
 	ID                 int                      `json:"id"`
 	UUID               uuid.UUID                `json:"uuid"`
 	ProviderID         int                      `json:"provider_id"`
-	Phone              string                   `json:"phone"`
+	Phone              string                   `json:"phone"`                   // E.164
+	PhoneDisplay       string                   `json:"phone_display"`           // "(NXX) NXX-XXXX ext. N"
 	PhoneType          string                   `json:"phone_type"`
 	Extension          NullString               `json:"extension"`
 	IsCorrect          NullBool                 `json:"is_correct"`
-	CorrectedPhone     NullString               `json:"corrected_phone"`
+	CorrectedPhone     NullString               `json:"corrected_phone"`         // E.164
+	CorrectedDisplay   string                   `json:"corrected_phone_display,omitempty"`
 	ValidationNotes    NullString               `json:"validation_notes"`
 	ValidationMetadata map[string]interface{}   `json:"validation_metadata,omitempty"`
 	CallAttempts       []CallAttemptRecord      `json:"call_attempts,omitempty"`
 	IsFlagged          bool                     `json:"is_flagged"`
 	FlagReason         NullString               `json:"flag_reason"`
+	FlagType           NullString               `json:"flag_type,omitempty"`     // from an active global flag on the number
+	FlagSeverity       NullInt64                `json:"flag_severity,omitempty"` // 1-5, from the same flag
+	AreaCodeRegion     string                   `json:"area_code_region,omitempty"` // state(s) or country the area code is assigned to
+	AreaCodeMismatch   bool                     `json:"area_code_mismatch"`         // area code is outside every address state
 	ConfidenceScore    NullFloat64              `json:"confidence_score"`
 	ValidatedBy        NullInt64                `json:"validated_by"`
 	ValidatedAt        NullTime                 `json:"validated_at"`
@@ -94,12 +103,68 @@ type ValidationSession struct {
 	ValidationResults map[string]interface{}   `json:"validation_results,omitempty"`
 	Notes             NullString               `json:"notes"`
 	QualityScore      NullFloat64              `json:"quality_score"`
+	CallbackAt        NullTime                 `json:"callback_at"`
 	CreatedAt         time.Time                `json:"created_at"`
 	UpdatedAt         time.Time                `json:"updated_at"`
 	CreatedBy         NullInt64                `json:"created_by,omitempty"`
 	UpdatedBy         NullInt64                `json:"updated_by,omitempty"`
 }
 
+// SessionLockStatus reports how long a session lock remains valid
+type SessionLockStatus struct {
+	SessionID        int       `json:"session_id"`
+	LockedAt         time.Time `json:"locked_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RemainingSeconds int       `json:"remaining_seconds"`
+}
+
+type ReleaseSessionRequest struct {
+	ReasonCode  string `json:"reason_code"`
+	Notes       string `json:"notes,omitempty"`
+	ExcludeSelf bool   `json:"exclude_self,omitempty"` // don't hand this provider back to the releasing agent
+}
+
+type HoldSessionRequest struct {
+	CallbackAt time.Time `json:"callback_at"`
+	Notes      string    `json:"notes,omitempty"`
+}
+
+// ScheduledCallback is an on-hold session waiting for its callback time
+type ScheduledCallback struct {
+	SessionID    int        `json:"session_id"`
+	ProviderID   int        `json:"provider_id"`
+	NPI          string     `json:"npi"`
+	ProviderName string     `json:"provider_name"`
+	CallbackAt   time.Time  `json:"callback_at"`
+	Notes        NullString `json:"notes"`
+	IsDue        bool       `json:"is_due"`
+}
+
+// ActiveSession is an in-progress session as shown to supervisors
+type ActiveSession struct {
+	SessionID      int        `json:"session_id"`
+	ProviderID     int        `json:"provider_id"`
+	NPI            string     `json:"npi"`
+	ProviderName   string     `json:"provider_name"`
+	UserID         int        `json:"user_id"`
+	AgentEmail     string     `json:"agent_email"`
+	AgentFirstName NullString `json:"agent_first_name"`
+	AgentLastName  NullString `json:"agent_last_name"`
+	StartedAt      time.Time  `json:"started_at"`
+	LockedAt       time.Time  `json:"locked_at"`
+	LockAgeSeconds int        `json:"lock_age_seconds"`
+	LockExpiresAt  time.Time  `json:"lock_expires_at"`
+}
+
+type ForceReleaseRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+type ReassignSessionRequest struct {
+	UserID      int    `json:"user_id"`
+	HandoffNote string `json:"handoff_note"`
+}
+
 type AddressPhoneRecord struct {
 	ID      string          `json:"id"`       // composite identifier: "addr_id-phone_id"
 	Address ProviderAddress `json:"address"`
@@ -112,22 +177,99 @@ type ProviderValidationData struct {
 	Addresses           []ProviderAddress    `json:"addresses"`
 	Phones              []ProviderPhone      `json:"phones"`
 	ValidationSession   *ValidationSession   `json:"validation_session,omitempty"`
+	ProviderLocalTime   *ProviderLocalTime   `json:"provider_local_time,omitempty"`
+	Warnings            []string             `json:"warnings,omitempty"` // data-quality issues to check before dialing
+}
+
+// ProviderLocalTime is the current time at the provider's main office
+type ProviderLocalTime struct {
+	Timezone            string     `json:"timezone,omitempty"` // IANA zone; empty when the location is unknown
+	LocalTime           *time.Time `json:"local_time,omitempty"`
+	WithinCallingWindow bool       `json:"within_calling_window"`
+	CallingWindowStart  string     `json:"calling_window_start"`
+	CallingWindowEnd    string     `json:"calling_window_end"`
 }
 
 type AddressValidation struct {
-	AddressID         int    `json:"address_id"`
-	IsCorrect         bool   `json:"is_correct"`
-	CorrectedAddress1 string `json:"corrected_address1,omitempty"`
-	CorrectedAddress2 string `json:"corrected_address2,omitempty"`
-	CorrectedCity     string `json:"corrected_city,omitempty"`
-	CorrectedState    string `json:"corrected_state,omitempty"`
-	CorrectedZip      string `json:"corrected_zip,omitempty"`
+	AddressID            int    `json:"address_id"`
+	IsCorrect            bool   `json:"is_correct"`
+	CorrectedAddress1    string `json:"corrected_address1,omitempty"`
+	CorrectedAddress2    string `json:"corrected_address2,omitempty"`
+	CorrectedCity        string `json:"corrected_city,omitempty"`
+	CorrectedState       string `json:"corrected_state,omitempty"`
+	CorrectedZip         string `json:"corrected_zip,omitempty"`
+	Propagate            bool   `json:"propagate,omitempty"`              // apply to unvalidated copies of this address at other providers
+	PropagateWithinGroup bool   `json:"propagate_within_group,omitempty"` // only providers sharing the provider_group or GNPI
 }
 
 type PhoneValidation struct {
 	PhoneID        int    `json:"phone_id"`
 	IsCorrect      bool   `json:"is_correct"`
 	CorrectedPhone string `json:"corrected_phone,omitempty"`
+	Propagate      bool   `json:"propagate,omitempty"` // apply to unvalidated phones with the same number
+}
+
+// AddressPropagationPreview counts the records a propagated address validation would change
+type AddressPropagationPreview struct {
+	AddressID         int    `json:"address_id"`
+	NormalizedAddress string `json:"normalized_address"`
+	WithinGroup       bool   `json:"within_group"`
+	Records           int    `json:"records"`
+	Providers         int    `json:"providers"`
+	SkippedInSession  int    `json:"skipped_in_session"`
+}
+
+// AddressPropagation is one propagated address validation, undoable by supervisors
+type AddressPropagation struct {
+	ID               int       `json:"id"`
+	SourceAddressID  int       `json:"source_address_id"`
+	SourceProviderID int       `json:"source_provider_id"`
+	SourceSessionID  NullInt64 `json:"source_session_id"`
+	WithinGroup      bool      `json:"within_group"`
+	TargetCount      int       `json:"target_count"`
+	PropagatedBy     int       `json:"propagated_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	UndoneBy         NullInt64 `json:"undone_by"`
+	UndoneAt         NullTime  `json:"undone_at"`
+	RestoredCount    NullInt64 `json:"restored_count"`
+}
+
+// AddressStandardization summarizes a pass of the USPS standardizer over stored addresses
+type AddressStandardization struct {
+	Scanned      int `json:"scanned"`
+	Standardized int `json:"standardized"` // address_key set or changed
+	Invalid      int `json:"invalid"`      // state or ZIP that does not standardize
+}
+
+// DuplicateAddressGroup is a set of one provider's addresses that standardize identically
+type DuplicateAddressGroup struct {
+	ProviderID        int    `json:"provider_id"`
+	NPI               string `json:"npi"`
+	ProviderName      string `json:"provider_name"`
+	NormalizedAddress string `json:"normalized_address"`
+	AddressIDs        []int  `json:"address_ids"`
+}
+
+// AreaCodeMismatch is a phone whose area code is assigned outside every state
+// its provider has an address in
+type AreaCodeMismatch struct {
+	PhoneID        int      `json:"phone_id"`
+	ProviderID     int      `json:"provider_id"`
+	NPI            string   `json:"npi"`
+	ProviderName   string   `json:"provider_name"`
+	Phone          string   `json:"phone"` // E.164; the correction when there is one
+	AreaCode       string   `json:"area_code"`
+	AreaCodeRegion string   `json:"area_code_region"`
+	AddressStates  []string `json:"address_states"`
+}
+
+// PropagationPreview counts the records a propagated validation would change
+type PropagationPreview struct {
+	PhoneID          int    `json:"phone_id"`
+	NormalizedPhone  string `json:"normalized_phone"`
+	Records          int    `json:"records"`            // unvalidated phones that would be updated
+	Providers        int    `json:"providers"`          // distinct providers among them
+	SkippedInSession int    `json:"skipped_in_session"` // matches held back while the provider has another open or on-hold session
 }
 
 type ValidationUpdate struct {
@@ -152,6 +294,11 @@ type CallAttemptRecord struct {
 	Status        string    `json:"status"` // successful, no_answer, busy, disconnected, invalid
 	Notes         string    `json:"notes,omitempty"`
 	Duration      int       `json:"duration,omitempty"` // seconds
+	PhoneID       int       `json:"phone_id,omitempty"`
+	Phone         string    `json:"phone,omitempty"` // number as dialed
+	SessionID     int       `json:"session_id,omitempty"`
+	UserID        int       `json:"user_id,omitempty"`
+	CallID        string    `json:"call_id,omitempty"` // telephony call that produced this attempt
 }
 
 type FlaggedPhone struct {
@@ -171,6 +318,27 @@ type FlaggedPhone struct {
 	UpdatedAt  time.Time              `json:"updated_at"`
 }
 
+type FlagPhoneRequest struct {
+	Phone    string `json:"phone"`
+	FlagType string `json:"flag_type,omitempty"` // invalid (default), disconnected, wrong_number, fax, do_not_call, spam
+	Reason   string `json:"reason,omitempty"`
+	Severity int    `json:"severity,omitempty"` // 1 (default) to 5
+}
+
+type ResolveFlagRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// FlaggedPhoneFilter narrows a flagged phone search
+type FlaggedPhoneFilter struct {
+	Query           string // matches number digits or reason text
+	FlagType        string
+	MinSeverity     int
+	IncludeResolved bool
+	Limit           int
+	Offset          int
+}
+
 type ValidationStats struct {
 	UserID                  int         `json:"user_id"`
 	Email                   string      `json:"email"`
@@ -183,16 +351,176 @@ type ValidationStats struct {
 	LastValidationDate      NullTime    `json:"last_validation_date"`
 }
 
+// SessionHistoryEntry is a validation session with the agent who worked it
+type SessionHistoryEntry struct {
+	ValidationSession
+	AgentEmail     string     `json:"agent_email"`
+	AgentFirstName NullString `json:"agent_first_name"`
+	AgentLastName  NullString `json:"agent_last_name"`
+}
+
+// ProviderDetail is a provider with every address and phone, original and
+// corrected, and its full validation history, newest session first
+type ProviderDetail struct {
+	Provider          Provider              `json:"provider"`
+	ValidationStatus  string                `json:"validation_status"` // outcome as in the outcomes export
+	DispositionReason NullString            `json:"disposition_reason"`
+	Addresses         []ProviderAddress     `json:"addresses"`
+	Phones            []ProviderPhone       `json:"phones"`
+	Sessions          []SessionHistoryEntry `json:"sessions"`
+	Warnings          []string              `json:"warnings,omitempty"`
+}
+
 type ProviderSearchResult struct {
-	ID           int       `json:"id"`
+	ID               int       `json:"id"`
+	UUID             uuid.UUID `json:"uuid"`
+	NPI              string    `json:"npi"`
+	ProviderName     string    `json:"provider_name"`
+	Specialty        string    `json:"specialty"`
+	ProviderGroup    string    `json:"provider_group"`
+	States           []string  `json:"states"`            // address states, corrections preferred
+	ValidationStatus string    `json:"validation_status"` // outcome as in the outcomes export
+	IsActive         bool      `json:"is_active"`
+	Rank             float32   `json:"rank"`
+}
+
+// InvalidNPI is a stored provider whose NPI fails the check digit
+type InvalidNPI struct {
+	ProviderID   int       `json:"provider_id"`
 	UUID         uuid.UUID `json:"uuid"`
 	NPI          string    `json:"npi"`
 	ProviderName string    `json:"provider_name"`
-	Specialty    string    `json:"specialty"`
-	ProviderGroup string   `json:"provider_group"`
-	Rank         float32   `json:"rank"`
+	IsActive     bool      `json:"is_active"`
+	Reason       string    `json:"reason"`
+}
+
+// QuarantinedNPI is an import row the loader set aside for its NPI
+type QuarantinedNPI struct {
+	ID           int        `json:"id"`
+	NPI          string     `json:"npi"`
+	ProviderName NullString `json:"provider_name"`
+	Reason       string     `json:"reason"`
+	Record       []string   `json:"record"` // the raw CSV fields
+	RowNumber    NullInt64  `json:"row_number"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ProviderSearchFilter narrows a provider search. Empty fields match everything.
+type ProviderSearchFilter struct {
+	Query            string // full text over name, specialty, group and NPI, or a phone number
+	NPIPrefix        string
+	Specialty        string
+	State            string // any address state, original or corrected
+	Group            string // provider group name or GNPI
+	ValidationStatus string // pending, in-progress, corrected, validated or inconclusive-unreachable
+	Active           *bool  // nil matches active and inactive providers
+	Limit            int
+	Offset           int
+}
+
+// ProviderSearchPage is one page of search results with the total match count
+type ProviderSearchPage struct {
+	Results []ProviderSearchResult `json:"results"`
+	Total   int                    `json:"total"`
+	Limit   int                    `json:"limit"`
+	Offset  int                    `json:"offset"`
+}
+
+type ProviderPriorityUpdate struct {
+	Priority int        `json:"priority"`
+	DueDate  *time.Time `json:"due_date,omitempty"`
+}
+
+// QueueItem is a provider as it stands in the work queue
+type QueueItem struct {
+	ProviderID        int       `json:"provider_id"`
+	NPI               string    `json:"npi"`
+	ProviderName      string    `json:"provider_name"`
+	Priority          int       `json:"priority"`
+	EffectivePriority int       `json:"effective_priority"`
+	DueDate           NullTime  `json:"due_date"`
+	QueuedAt          time.Time `json:"queued_at"`
 }
 
 type CallAttemptRequest struct {
-	AttemptNumber int `json:"attempt_number"` // 1 or 2
-}
\ No newline at end of file
+	AttemptNumber int    `json:"attempt_number"` // 1 up to the policy's max_attempts
+	PhoneID       int    `json:"phone_id"`
+	Outcome       string `json:"outcome"` // successful, no_answer, busy, disconnected, invalid
+	Duration      int    `json:"duration,omitempty"` // seconds
+	Notes         string `json:"notes,omitempty"`
+	CallID        string `json:"-"` // set when a telephony hangup event logs the attempt
+}
+
+type DialRequest struct {
+	PhoneID       int `json:"phone_id"`
+	AttemptNumber int `json:"attempt_number,omitempty"` // defaults to the next attempt
+}
+
+// TelephonyCall is a click-to-dial call and what the carrier reported about it
+type TelephonyCall struct {
+	ID            uuid.UUID  `json:"id"`
+	SessionID     int        `json:"session_id"`
+	PhoneID       int        `json:"phone_id"`
+	UserID        int        `json:"user_id"`
+	AttemptNumber int        `json:"attempt_number"`
+	Dialer        string     `json:"dialer"`
+	DialedNumber  string     `json:"dialed_number"`
+	Status        string     `json:"status"`  // dialing, ringing, answered, ended, failed
+	Outcome       NullString `json:"outcome"` // call attempt outcome once ended
+	HangupCause   NullString `json:"hangup_cause"`
+	Duration      NullInt64  `json:"duration"` // talk time in seconds
+	RingingAt     NullTime   `json:"ringing_at"`
+	AnsweredAt    NullTime   `json:"answered_at"`
+	EndedAt       NullTime   `json:"ended_at"`
+	RecordError   NullString `json:"record_error,omitempty"` // why the attempt could not be logged
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CallAttemptStep constrains one attempt of a CallAttemptPolicy. Spacing is
+// measured from the previous attempt; every rule that is set must be met.
+type CallAttemptStep struct {
+	MinSpacingMinutes int      `json:"min_spacing_minutes,omitempty"` // wall-clock minutes
+	MinBusinessHours  int      `json:"min_business_hours,omitempty"`  // hours elapsed inside the calling window on business days
+	MinBusinessDays   int      `json:"min_business_days,omitempty"`   // lands on the Nth business day after
+	DifferentWeekday  bool     `json:"different_weekday,omitempty"`   // weekday not used by an earlier attempt
+	AllowedOutcomes   []string `json:"allowed_outcomes,omitempty"`    // empty allows any outcome
+}
+
+// CallAttemptPolicy sets how many times and how often a provider may be called.
+// Steps[i] governs attempt i+1; the last step repeats for any later attempts.
+type CallAttemptPolicy struct {
+	Campaign    string            `json:"campaign"`
+	MaxAttempts int               `json:"max_attempts"`
+	Steps       []CallAttemptStep `json:"steps"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	UpdatedBy   NullInt64         `json:"updated_by,omitempty"`
+}
+
+// CallAttemptStatus tells the UI which attempt comes next and when it may be made
+type CallAttemptStatus struct {
+	SessionID            int                `json:"session_id"`
+	Campaign             string             `json:"campaign"`
+	MaxAttempts          int                `json:"max_attempts"`
+	AttemptsMade         int                `json:"attempts_made"`
+	NextAttemptNumber    int                `json:"next_attempt_number"` // 0 once attempts are exhausted
+	NextAttemptAllowedAt *time.Time         `json:"next_attempt_allowed_at,omitempty"`
+	AllowedOutcomes      []string           `json:"allowed_outcomes,omitempty"`
+	ProviderLocalTime    *ProviderLocalTime `json:"provider_local_time,omitempty"`
+	Warnings             []string           `json:"warnings,omitempty"`
+	Disposition          string             `json:"disposition,omitempty"` // set when the attempt closed the provider out
+}
+
+type UnreachableRequest struct {
+	Notes string `json:"notes,omitempty"`
+}
+
+// ProviderOutcome is one row of the validation outcomes export
+type ProviderOutcome struct {
+	ProviderID        int        `json:"provider_id"`
+	NPI               string     `json:"npi"`
+	ProviderName      string     `json:"provider_name"`
+	Outcome           string     `json:"outcome"` // validated, corrected, pending, in-progress, inconclusive-unreachable
+	DispositionReason NullString `json:"disposition_reason"`
+	DispositionAt     NullTime   `json:"disposition_at"`
+	LastCompletedAt   NullTime   `json:"last_completed_at"`
+}
@@ -13,6 +13,7 @@ type User struct {
 	Password    string                 `json:"-"`
 	FirstName   NullString             `json:"first_name"`
 	LastName    NullString             `json:"last_name"`
+	Role        string                 `json:"role"`
 	IsActive    bool                   `json:"is_active"`
 	LastLoginAt NullTime               `json:"last_login_at"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
@@ -35,4 +36,28 @@ type RegisterRequest struct {
 type AuthResponse struct {
 	Token string `json:"token"`
 	User  User   `json:"user"`
-}
\ No newline at end of file
+}
+
+// UserRoleUpdate grants agent, supervisor or admin
+type UserRoleUpdate struct {
+	Role string `json:"role"`
+}
+
+// UserSkill tags an agent with a state, language or specialty used for routing
+type UserSkill struct {
+	ID         int       `json:"id"`
+	UserID     int       `json:"user_id"`
+	SkillType  string    `json:"skill_type"` // state, language, specialty
+	SkillValue string    `json:"skill_value"`
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  NullInt64 `json:"created_by,omitempty"`
+}
+
+type UserSkillInput struct {
+	SkillType  string `json:"skill_type"`
+	SkillValue string `json:"skill_value"`
+}
+
+type UserSkillsUpdate struct {
+	Skills []UserSkillInput `json:"skills"`
+}
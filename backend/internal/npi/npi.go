@@ -0,0 +1,65 @@
+// Package npi validates National Provider Identifiers. The tenth digit of an
+// NPI is a Luhn check digit computed over the nine leading digits prefixed
+// with 80840, the ISO health industry issuer prefix.
+package npi
+
+import "errors"
+
+var (
+	ErrFormat     = errors.New("NPI must be 10 digits")
+	ErrCheckDigit = errors.New("NPI check digit does not match")
+)
+
+// prefixSum is the Luhn contribution of the 80840 prefix
+const prefixSum = 24
+
+// Validate reports why npi is not a valid NPI, or nil when it is
+func Validate(npi string) error {
+	if len(npi) != 10 || !isDigits(npi) {
+		return ErrFormat
+	}
+	if CheckDigit(npi[:9]) != npi[9] {
+		return ErrCheckDigit
+	}
+	return nil
+}
+
+// Valid reports whether npi is a valid NPI
+func Valid(npi string) bool {
+	return Validate(npi) == nil
+}
+
+// CheckDigit returns the check digit for the nine leading digits of an NPI.
+// It panics if base is not nine digits.
+func CheckDigit(base string) byte {
+	if len(base) != 9 || !isDigits(base) {
+		panic("npi: CheckDigit needs nine digits")
+	}
+	sum := prefixSum
+	// Every other digit is doubled, starting with the rightmost
+	for i := 8; i >= 0; i-- {
+		d := int(base[i] - '0')
+		if (8-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// Complete appends the check digit to the nine leading digits of an NPI
+func Complete(base string) string {
+	return base + string(CheckDigit(base))
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package npi
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		npi  string
+		want error
+	}{
+		// CMS's worked example
+		{"1234567893", nil},
+		{"1245319599", nil},
+		// Each single-digit typo of the example
+		{"1234567894", ErrCheckDigit},
+		{"1234567883", ErrCheckDigit},
+		{"2234567893", ErrCheckDigit},
+		// Swapped adjacent digits
+		{"1234567839", ErrCheckDigit},
+		{"123456789", ErrFormat},
+		{"12345678930", ErrFormat},
+		{"123456789X", ErrFormat},
+		{"123-456-78", ErrFormat},
+		{"", ErrFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.npi, func(t *testing.T) {
+			if got := Validate(tt.npi); got != tt.want {
+				t.Errorf("Validate(%q) = %v, want %v", tt.npi, got, tt.want)
+			}
+			if got := Valid(tt.npi); got != (tt.want == nil) {
+				t.Errorf("Valid(%q) = %v, want %v", tt.npi, got, tt.want == nil)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	tests := []struct {
+		base, want string
+	}{
+		{"123456789", "1234567893"},
+		{"124531959", "1245319599"},
+		{"000000000", "0000000006"},
+	}
+
+	for _, tt := range tests {
+		if got := Complete(tt.base); got != tt.want {
+			t.Errorf("Complete(%q) = %q, want %q", tt.base, got, tt.want)
+		}
+		if !Valid(Complete(tt.base)) {
+			t.Errorf("Complete(%q) is not a valid NPI", tt.base)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package phone
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"strings"
+)
+
+//go:embed areacodes.csv
+var areaCodeData []byte
+
+// AreaCode is where a NANP area code is assigned
+type AreaCode struct {
+	Code     string   `json:"code"`
+	Country  string   `json:"country,omitempty"` // ISO 3166 code; empty for toll-free and premium codes
+	States   []string `json:"states,omitempty"`  // US states or Canadian provinces
+	Timezone string   `json:"timezone,omitempty"`
+}
+
+var areaCodes = map[string]AreaCode{}
+
+func init() {
+	reader := csv.NewReader(bytes.NewReader(areaCodeData))
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		panic("phone: embedded area codes: " + err.Error())
+	}
+	for _, row := range rows[1:] {
+		areaCodes[row[0]] = AreaCode{
+			Code:     row[0],
+			Country:  row[1],
+			States:   strings.Fields(row[2]),
+			Timezone: row[3],
+		}
+	}
+}
+
+// LookupAreaCode returns what the reference knows about a three-digit area code
+func LookupAreaCode(code string) (AreaCode, bool) {
+	area, ok := areaCodes[code]
+	return area, ok
+}
+
+// AreaCode returns the reference entry for the number's area code
+func (n Number) AreaCode() (AreaCode, bool) {
+	return LookupAreaCode(n.Area)
+}
+
+// Geographic reports whether the area code serves a fixed region. Toll-free
+// and premium codes do not.
+func (a AreaCode) Geographic() bool {
+	return a.Country != ""
+}
+
+// Serves reports whether the area code is assigned to a US state; codes
+// outside the US serve none.
+func (a AreaCode) Serves(state string) bool {
+	if a.Country != "US" {
+		return false
+	}
+	for _, s := range a.States {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// Region names where the area code is assigned: its states or provinces
+// ("NY", "NS/PE"), or the country for the rest of the NANP ("JM")
+func (a AreaCode) Region() string {
+	if len(a.States) > 0 {
+		return strings.Join(a.States, "/")
+	}
+	return a.Country
+}
@@ -0,0 +1,36 @@
+package phone
+
+import "testing"
+
+func TestAreaCode(t *testing.T) {
+	tests := []struct {
+		code       string
+		state      string
+		serves     bool
+		geographic bool
+		region     string
+	}{
+		{"217", "IL", true, true, "IL"},
+		{"217", "IN", false, true, "IL"},
+		{"800", "IL", false, false, ""},
+		{"902", "NS", false, true, "NS/PE"},
+		{"876", "FL", false, true, "JM"},
+	}
+
+	for _, tt := range tests {
+		area, ok := LookupAreaCode(tt.code)
+		if !ok {
+			t.Errorf("LookupAreaCode(%q) not found", tt.code)
+			continue
+		}
+		if got := area.Serves(tt.state); got != tt.serves {
+			t.Errorf("%s.Serves(%q) = %v, want %v", tt.code, tt.state, got, tt.serves)
+		}
+		if got := area.Geographic(); got != tt.geographic {
+			t.Errorf("%s.Geographic() = %v, want %v", tt.code, got, tt.geographic)
+		}
+		if got := area.Region(); got != tt.region {
+			t.Errorf("%s.Region() = %q, want %q", tt.code, got, tt.region)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Package phone parses US and other NANP phone numbers into a canonical
+// E.164 form so the same number typed different ways compares equal.
+package phone
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrEmpty     = errors.New("phone number is empty")
+	ErrFormat    = errors.New("phone number contains invalid characters")
+	ErrNotNANP   = errors.New("phone number is not a US/NANP number")
+	ErrLength    = errors.New("phone number must have 10 digits")
+	ErrAreaCode  = errors.New("phone number has an invalid area code")
+	ErrExchange  = errors.New("phone number has an invalid exchange")
+	ErrFictional = errors.New("phone number is in the fictional 555-01XX range")
+	ErrExtension = errors.New("phone extension must be 1-6 digits")
+)
+
+// maxExtensionDigits bounds extensions; longer runs are usually a second number
+const maxExtensionDigits = 6
+
+// extensionPattern matches a trailing extension: "x12", "ext. 12", "extension 12", "#12"
+var extensionPattern = regexp.MustCompile(`(?i)\s*(?:,|;|#|x|ext\.?|extn\.?|extension)\s*(\d*)\s*$`)
+
+// Number is a parsed NANP number: NPA (area code), NXX (exchange) and line,
+// plus an optional extension
+type Number struct {
+	Area      string
+	Exchange  string
+	Line      string
+	Extension string
+}
+
+// Parse reads a NANP number in any common format: "(217) 555-1234",
+// "217.555.1234", "+1 217 555 1234", "1-217-555-1234 ext. 5". Numbers
+// that cannot be dialed (bad area code or exchange, fictional 555-01XX)
+// are rejected.
+func Parse(s string) (Number, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Number{}, ErrEmpty
+	}
+
+	var n Number
+	if m := extensionPattern.FindStringSubmatchIndex(s); m != nil && m[0] > 0 {
+		n.Extension = s[m[2]:m[3]]
+		if n.Extension == "" || len(n.Extension) > maxExtensionDigits {
+			return Number{}, ErrExtension
+		}
+		s = s[:m[0]]
+	}
+
+	var digits strings.Builder
+	international := false
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/':
+		default:
+			return Number{}, ErrFormat
+		}
+	}
+
+	d := digits.String()
+	if international && !strings.HasPrefix(d, "1") {
+		return Number{}, ErrNotNANP
+	}
+	if len(d) == 11 && d[0] == '1' {
+		d = d[1:]
+	}
+	if len(d) != 10 {
+		return Number{}, ErrLength
+	}
+	n.Area, n.Exchange, n.Line = d[0:3], d[3:6], d[6:10]
+
+	if err := n.validate(); err != nil {
+		return Number{}, err
+	}
+	return n, nil
+}
+
+// validate applies the NANP numbering plan rules
+func (n Number) validate() error {
+	// Area codes are NXX; N11 are service codes, N9X and 37X/96X are
+	// reserved for expansion and never assigned
+	switch {
+	case n.Area[0] < '2',
+		n.Area[1:] == "11",
+		n.Area[1] == '9',
+		strings.HasPrefix(n.Area, "37"),
+		strings.HasPrefix(n.Area, "96"):
+		return ErrAreaCode
+	}
+	// Exchanges are NXX and N11 is reserved for service codes
+	if n.Exchange[0] < '2' || n.Exchange[1:] == "11" {
+		return ErrExchange
+	}
+	// 555-0100 through 555-0199 are set aside for fiction
+	if n.Exchange == "555" && strings.HasPrefix(n.Line, "01") {
+		return ErrFictional
+	}
+	return nil
+}
+
+// Normalize parses s and returns its E.164 form without the extension
+func Normalize(s string) (string, bool) {
+	n, err := Parse(s)
+	if err != nil {
+		return "", false
+	}
+	return n.E164(), true
+}
+
+// Format returns the display form of a stored number, or s unchanged when
+// it does not parse
+func Format(s string) string {
+	n, err := Parse(s)
+	if err != nil {
+		return s
+	}
+	return n.Display()
+}
+
+// Digits returns the ten-digit national number
+func (n Number) Digits() string {
+	return n.Area + n.Exchange + n.Line
+}
+
+// E164 returns the canonical "+1NXXNXXXXXX" form; the extension is not part of it
+func (n Number) E164() string {
+	return "+1" + n.Digits()
+}
+
+// Display returns "(NXX) NXX-XXXX", with " ext. N" when there is an extension
+func (n Number) Display() string {
+	s := "(" + n.Area + ") " + n.Exchange + "-" + n.Line
+	if n.Extension != "" {
+		s += " ext. " + n.Extension
+	}
+	return s
+}
+
+// String returns the display form
+func (n Number) String() string {
+	return n.Display()
+}
@@ -0,0 +1,95 @@
+package phone
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in        string
+		e164      string
+		extension string
+		err       error
+	}{
+		{"(217) 555-1234", "+12175551234", "", nil},
+		{"217.555.1234", "+12175551234", "", nil},
+		{"217/555-1234", "+12175551234", "", nil},
+		{"+1 217 555 1234", "+12175551234", "", nil},
+		{"1-217-555-1234", "+12175551234", "", nil},
+		{"12175551234", "+12175551234", "", nil},
+
+		// Extensions
+		{"1-217-555-1234 ext. 5", "+12175551234", "5", nil},
+		{"217-555-1234 x204", "+12175551234", "204", nil},
+		{"217-555-1234 X 204", "+12175551234", "204", nil},
+		{"217-555-1234 extension 12", "+12175551234", "12", nil},
+		{"217-555-1234 extn. 12", "+12175551234", "12", nil},
+		{"217-555-1234#12", "+12175551234", "12", nil},
+		{"217-555-1234, 123456", "+12175551234", "123456", nil},
+		{"217-555-1234 ext", "", "", ErrExtension},
+		{"217-555-1234 x1234567", "", "", ErrExtension},
+
+		// 555 is only fictional for lines 0100-0199
+		{"217-555-0100", "", "", ErrFictional},
+		{"217-555-0199", "", "", ErrFictional},
+		{"217-555-0200", "+12175550200", "", nil},
+		{"217-555-0099", "+12175550099", "", nil},
+
+		// Area codes: no leading 0 or 1, no N11 service codes, no N9X,
+		// 37X or 96X expansion codes
+		{"017-555-1234", "", "", ErrAreaCode},
+		{"117-555-1234", "", "", ErrAreaCode},
+		{"211-555-1234", "", "", ErrAreaCode},
+		{"411-555-1234", "", "", ErrAreaCode},
+		{"911-555-1234", "", "", ErrAreaCode},
+		{"297-555-1234", "", "", ErrAreaCode},
+		{"372-555-1234", "", "", ErrAreaCode},
+		{"965-555-1234", "", "", ErrAreaCode},
+
+		// Exchanges: no leading 0 or 1, no N11
+		{"217-055-1234", "", "", ErrExchange},
+		{"217-155-1234", "", "", ErrExchange},
+		{"217-411-1234", "", "", ErrExchange},
+
+		{"", "", "", ErrEmpty},
+		{"   ", "", "", ErrEmpty},
+		{"217-555-123", "", "", ErrLength},
+		{"217-555-12345", "", "", ErrLength},
+		{"2-217-555-1234", "", "", ErrLength},
+		{"217-555-CALL", "", "", ErrFormat},
+		{"+44 20 7946 0958", "", "", ErrNotNANP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			n, err := Parse(tt.in)
+			if err != tt.err {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if n.E164() != tt.e164 {
+				t.Errorf("Parse(%q).E164() = %q, want %q", tt.in, n.E164(), tt.e164)
+			}
+			if n.Extension != tt.extension {
+				t.Errorf("Parse(%q).Extension = %q, want %q", tt.in, n.Extension, tt.extension)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"+12175551234", "(217) 555-1234"},
+		{"217.555.1234 x5", "(217) 555-1234 ext. 5"},
+		// Numbers that do not parse are shown as stored
+		{"555-CALL", "555-CALL"},
+	}
+
+	for _, tt := range tests {
+		if got := Format(tt.in); got != tt.want {
+			t.Errorf("Format(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/address"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/zipref"
+)
+
+// standardizeBatchSize is how many addresses StandardizeAddresses keys per transaction
+const standardizeBatchSize = 500
+
+// storedAddress is an address row as StandardizeAddresses reads it
+type storedAddress struct {
+	id      int
+	address address.Address
+	key     string
+}
+
+// checkZip compares an address with the ZIP reference. A ZIP in another
+// state fails with ErrZipMismatch when ZIP_CHECK_REFUSE is set; otherwise the
+// issues are returned as warnings.
+func checkZip(state, city, zip string) ([]zipref.Issue, error) {
+	issues := zipref.Check(state, city, zip)
+	if config.ZipCheckRefuse {
+		for _, issue := range issues {
+			if issue.Blocking() {
+				return nil, ErrZipMismatch
+			}
+		}
+	}
+	return issues, nil
+}
+
+// checkCorrectedAddress checks a correction against the ZIP reference, taking
+// the fields the agent left alone from the stored address
+func checkCorrectedAddress(ctx context.Context, tx pgx.Tx, addressID int, corrected address.Address) ([]zipref.Issue, error) {
+	if corrected.State == "" && corrected.City == "" && corrected.Zip == "" {
+		return nil, nil
+	}
+
+	var state, city, zip string
+	err := tx.QueryRow(ctx, `
+		SELECT COALESCE(state, ''), COALESCE(city, ''), COALESCE(zip, '')
+		FROM provider_addresses WHERE id = $1
+	`, addressID).Scan(&state, &city, &zip)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, err
+	}
+	if corrected.State != "" {
+		state = corrected.State
+	}
+	if corrected.City != "" {
+		city = corrected.City
+	}
+	if corrected.Zip != "" {
+		zip = corrected.Zip
+	}
+	return checkZip(state, city, zip)
+}
+
+func issueMessages(issues []zipref.Issue) []string {
+	messages := make([]string, len(issues))
+	for i, issue := range issues {
+		messages[i] = issue.Message
+	}
+	return messages
+}
+
+// StandardizeAddresses sets address_key on stored addresses from their USPS
+// standard form, so rows loaded before standardization take part in
+// duplicate detection and propagation. The stored address is left as it was
+// loaded. Running it again changes nothing.
+func StandardizeAddresses(userID int) (*models.AddressStandardization, error) {
+	ctx := context.Background()
+
+	result := &models.AddressStandardization{}
+	lastID := 0
+	for {
+		batch, err := loadAddressBatch(ctx, lastID)
+		if err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return result, nil
+		}
+		lastID = batch[len(batch)-1].id
+		result.Scanned += len(batch)
+
+		err = database.WithTx(ctx, func(tx pgx.Tx) error {
+			for _, row := range batch {
+				if _, err := address.Standardize(row.address); err != nil {
+					result.Invalid++
+				}
+				key := address.Key(row.address)
+				if key == row.key {
+					continue
+				}
+
+				_, err = tx.Exec(ctx, `
+					UPDATE provider_addresses
+					SET address_key = $2, updated_by = $3, updated_at = CURRENT_TIMESTAMP
+					WHERE id = $1
+				`, row.id, key, userID)
+				if err != nil {
+					return err
+				}
+				result.Standardized++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// loadAddressBatch reads the next addresses after lastID, in id order
+func loadAddressBatch(ctx context.Context, lastID int) ([]storedAddress, error) {
+	rows, err := database.Query(ctx, `
+		SELECT id, address1, COALESCE(address2, ''), COALESCE(city, ''), COALESCE(state, ''), COALESCE(zip, ''),
+		       COALESCE(address_key, '')
+		FROM provider_addresses
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, lastID, standardizeBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []storedAddress
+	for rows.Next() {
+		var row storedAddress
+		err := rows.Scan(&row.id,
+			&row.address.Address1, &row.address.Address2, &row.address.City, &row.address.State, &row.address.Zip,
+			&row.key)
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, row)
+	}
+
+	return batch, rows.Err()
+}
+
+// ListDuplicateAddresses finds providers with several addresses that are the
+// same once standardized, largest groups first
+func ListDuplicateAddresses(limit int) ([]models.DuplicateAddressGroup, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT p.id, p.npi, p.provider_name, pa.address_key, array_agg(pa.id ORDER BY pa.id)
+		FROM provider_addresses pa
+		JOIN providers p ON p.id = pa.provider_id
+		WHERE pa.address_key IS NOT NULL
+		GROUP BY p.id, p.npi, p.provider_name, pa.address_key
+		HAVING COUNT(*) > 1
+		ORDER BY COUNT(*) DESC, p.id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	groups := []models.DuplicateAddressGroup{}
+	for rows.Next() {
+		var g models.DuplicateAddressGroup
+		if err := rows.Scan(&g.ProviderID, &g.NPI, &g.ProviderName, &g.NormalizedAddress, &g.AddressIDs); err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, rows.Err()
+}
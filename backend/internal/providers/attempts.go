@@ -0,0 +1,292 @@
+package providers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/calendar"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// Call attempt outcomes, matching the call_attempt_status enum
+const (
+	OutcomeSuccessful   = "successful"
+	OutcomeNoAnswer     = "no_answer"
+	OutcomeBusy         = "busy"
+	OutcomeDisconnected = "disconnected"
+	OutcomeInvalid      = "invalid"
+)
+
+var callOutcomes = map[string]bool{
+	OutcomeSuccessful:   true,
+	OutcomeNoAnswer:     true,
+	OutcomeBusy:         true,
+	OutcomeDisconnected: true,
+	OutcomeInvalid:      true,
+}
+
+// DefaultCampaign names the policy used by providers without a campaign
+const DefaultCampaign = "default"
+
+// maxPolicyAttempts matches the valid_max_attempts constraint
+const maxPolicyAttempts = 10
+
+// defaultCallAttemptPolicy applies if the default policy row is missing:
+// two attempts, the second at least one business day after the first
+func defaultCallAttemptPolicy() *models.CallAttemptPolicy {
+	return &models.CallAttemptPolicy{
+		Campaign:    DefaultCampaign,
+		MaxAttempts: 2,
+		Steps:       []models.CallAttemptStep{{}, {MinBusinessDays: 1}},
+	}
+}
+
+// policyStep returns the rules for attempt n; the last step repeats
+func policyStep(policy *models.CallAttemptPolicy, n int) models.CallAttemptStep {
+	if len(policy.Steps) == 0 {
+		return models.CallAttemptStep{}
+	}
+	if n > len(policy.Steps) {
+		n = len(policy.Steps)
+	}
+	return policy.Steps[n-1]
+}
+
+// loadCallAttemptPolicy returns the policy for the provider's campaign,
+// falling back to the default policy
+func loadCallAttemptPolicy(ctx context.Context, tx pgx.Tx, providerID int) (*models.CallAttemptPolicy, error) {
+	var policy models.CallAttemptPolicy
+	err := tx.QueryRow(ctx, `
+		SELECT campaign, max_attempts, steps, updated_at, updated_by
+		FROM call_attempt_policies
+		WHERE campaign = $2
+		   OR campaign = (SELECT metadata->>'campaign' FROM providers WHERE id = $1)
+		ORDER BY campaign = $2
+		LIMIT 1
+	`, providerID, DefaultCampaign).Scan(
+		&policy.Campaign, &policy.MaxAttempts, &policy.Steps,
+		&policy.UpdatedAt, &policy.UpdatedBy,
+	)
+	if err == pgx.ErrNoRows {
+		return defaultCallAttemptPolicy(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// callWindowHours returns the calling window as offsets from local midnight
+func callWindowHours() (open, close time.Duration) {
+	offset := func(clock string) time.Duration {
+		t, _ := time.Parse("15:04", clock)
+		return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return offset(config.CallWindowStart), offset(config.CallWindowEnd)
+}
+
+// attemptAllowedAt returns the earliest time attempt n may be made given the
+// attempts already recorded. The first attempt is never restricted.
+func attemptAllowedAt(policy *models.CallAttemptPolicy, cal *calendar.Calendar, attempts []models.CallAttemptRecord, n int) time.Time {
+	var previous time.Time
+	usedWeekdays := make(map[time.Weekday]bool)
+	for _, attempt := range attempts {
+		if attempt.AttemptNumber >= n {
+			continue
+		}
+		usedWeekdays[cal.Local(attempt.AttemptedAt).Weekday()] = true
+		if attempt.AttemptedAt.After(previous) {
+			previous = attempt.AttemptedAt
+		}
+	}
+	if previous.IsZero() {
+		return previous
+	}
+
+	step := policyStep(policy, n)
+	allowed := previous
+	if t := previous.Add(time.Duration(step.MinSpacingMinutes) * time.Minute); t.After(allowed) {
+		allowed = t
+	}
+	open, close := callWindowHours()
+	if t := cal.AddBusinessHours(previous, time.Duration(step.MinBusinessHours)*time.Hour, open, close); t.After(allowed) {
+		allowed = t
+	}
+	if t := cal.AddBusinessDays(previous, step.MinBusinessDays); t.After(allowed) {
+		allowed = t
+	}
+
+	// Move to the first business day on a weekday not yet tried
+	if step.DifferentWeekday {
+		allowed = cal.Local(allowed)
+		for i := 0; i < 7 && (usedWeekdays[allowed.Weekday()] || !cal.IsBusinessDay(allowed)); i++ {
+			allowed = calendar.StartOfDay(allowed).AddDate(0, 0, 1)
+		}
+	}
+
+	return allowed
+}
+
+// callAttemptStatus summarizes recorded attempts against the policy
+func callAttemptStatus(sessionID int, policy *models.CallAttemptPolicy, cal *calendar.Calendar, attempts []models.CallAttemptRecord) *models.CallAttemptStatus {
+	made := 0
+	for _, attempt := range attempts {
+		if attempt.AttemptNumber > made {
+			made = attempt.AttemptNumber
+		}
+	}
+
+	status := &models.CallAttemptStatus{
+		SessionID:    sessionID,
+		Campaign:     policy.Campaign,
+		MaxAttempts:  policy.MaxAttempts,
+		AttemptsMade: made,
+	}
+	if made >= policy.MaxAttempts {
+		return status
+	}
+
+	status.NextAttemptNumber = made + 1
+	if allowedAt := attemptAllowedAt(policy, cal, attempts, made+1); !allowedAt.IsZero() {
+		status.NextAttemptAllowedAt = &allowedAt
+	}
+	status.AllowedOutcomes = policyStep(policy, made+1).AllowedOutcomes
+	return status
+}
+
+// GetCallAttemptStatus reports the session's next attempt and when it may be made
+func GetCallAttemptStatus(sessionID int, userID int) (*models.CallAttemptStatus, error) {
+	ctx := context.Background()
+
+	var result *models.CallAttemptStatus
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var sessionUserID, providerID int
+		var attempts []models.CallAttemptRecord
+		err := tx.QueryRow(ctx, `
+			SELECT user_id, provider_id, call_attempts
+			FROM validation_sessions
+			WHERE id = $1
+		`, sessionID).Scan(&sessionUserID, &providerID, &attempts)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+			return err
+		}
+
+		policy, err := loadCallAttemptPolicy(ctx, tx, providerID)
+		if err != nil {
+			return err
+		}
+		cal, err := loadProviderCalendar(ctx, tx, providerID)
+		if err != nil {
+			return err
+		}
+
+		result = callAttemptStatus(sessionID, policy, cal, attempts)
+		result.ProviderLocalTime, err = providerLocalTime(ctx, tx, providerID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validateCallAttemptPolicy normalizes a policy and checks it is usable
+func validateCallAttemptPolicy(policy *models.CallAttemptPolicy) error {
+	policy.Campaign = strings.TrimSpace(policy.Campaign)
+	if policy.Campaign == "" || len(policy.Campaign) > 100 {
+		return ErrInvalidCallPolicy
+	}
+	if policy.MaxAttempts < 1 || policy.MaxAttempts > maxPolicyAttempts {
+		return ErrInvalidCallPolicy
+	}
+	if len(policy.Steps) > policy.MaxAttempts {
+		return ErrInvalidCallPolicy
+	}
+	for _, step := range policy.Steps {
+		if step.MinSpacingMinutes < 0 || step.MinBusinessHours < 0 || step.MinBusinessDays < 0 {
+			return ErrInvalidCallPolicy
+		}
+		for _, outcome := range step.AllowedOutcomes {
+			if !callOutcomes[outcome] {
+				return ErrInvalidCallPolicy
+			}
+		}
+	}
+	if policy.Steps == nil {
+		policy.Steps = []models.CallAttemptStep{}
+	}
+	return nil
+}
+
+// ListCallAttemptPolicies returns every policy, default first
+func ListCallAttemptPolicies() ([]models.CallAttemptPolicy, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT campaign, max_attempts, steps, updated_at, updated_by
+		FROM call_attempt_policies
+		ORDER BY campaign <> $1, campaign
+	`, DefaultCampaign)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policies := []models.CallAttemptPolicy{}
+	for rows.Next() {
+		var policy models.CallAttemptPolicy
+		err := rows.Scan(&policy.Campaign, &policy.MaxAttempts, &policy.Steps, &policy.UpdatedAt, &policy.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+// SetCallAttemptPolicy creates or replaces the policy for a campaign
+func SetCallAttemptPolicy(userID int, policy models.CallAttemptPolicy) error {
+	if err := validateCallAttemptPolicy(&policy); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+
+	return database.Exec(ctx, `
+		INSERT INTO call_attempt_policies (campaign, max_attempts, steps, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (campaign) DO UPDATE
+		SET max_attempts = EXCLUDED.max_attempts,
+		    steps = EXCLUDED.steps,
+		    updated_by = EXCLUDED.updated_by
+	`, policy.Campaign, policy.MaxAttempts, policy.Steps, userID)
+}
+
+// DeleteCallAttemptPolicy removes a campaign policy; the default cannot be removed
+func DeleteCallAttemptPolicy(campaign string) error {
+	if campaign == DefaultCampaign {
+		return ErrInvalidCallPolicy
+	}
+
+	ctx := context.Background()
+
+	tag, err := database.DB.Exec(ctx, `DELETE FROM call_attempt_policies WHERE campaign = $1`, campaign)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCallPolicyNotFound
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package providers
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/calendar"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+var closureStatePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// closureLookback bounds how far back closures are loaded; attempt spacing
+// is measured from recent attempts, so older closures never matter
+const closureLookback = 90
+
+// loadClosures returns admin closures that are nationwide or tied to one of states
+func loadClosures(ctx context.Context, tx pgx.Tx, states []string) ([]calendar.Holiday, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT closure_date, COALESCE(state, ''), name
+		FROM business_closures
+		WHERE closure_date >= CURRENT_DATE - $1::integer
+		  AND (state IS NULL OR state = ANY($2))
+		ORDER BY closure_date
+	`, closureLookback, states)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var closures []calendar.Holiday
+	for rows.Next() {
+		h := calendar.Holiday{Source: calendar.SourceClosure}
+		if err := rows.Scan(&h.Date, &h.State, &h.Name); err != nil {
+			return nil, err
+		}
+		closures = append(closures, h)
+	}
+
+	return closures, rows.Err()
+}
+
+// loadProviderCalendar builds the business calendar for the states the
+// provider has addresses in, keeping office hours in the provider's time zone
+func loadProviderCalendar(ctx context.Context, tx pgx.Tx, providerID int) (*calendar.Calendar, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT UPPER(COALESCE(corrected_state, state))
+		FROM provider_addresses
+		WHERE provider_id = $1 AND COALESCE(corrected_state, state) IS NOT NULL
+	`, providerID)
+	if err != nil {
+		return nil, err
+	}
+	states := []string{}
+	for rows.Next() {
+		var state string
+		if err := rows.Scan(&state); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	closures, err := loadClosures(ctx, tx, states)
+	if err != nil {
+		return nil, err
+	}
+	cal := calendar.New(closures, states...)
+
+	var timezone models.NullString
+	if err := tx.QueryRow(ctx, `SELECT provider_timezone($1)`, providerID).Scan(&timezone); err != nil {
+		return nil, err
+	}
+	if timezone.Valid {
+		if loc, err := time.LoadLocation(timezone.String); err == nil {
+			cal = cal.In(loc)
+		}
+	}
+
+	return cal, nil
+}
+
+// GetBusinessCalendar returns the calendar for a state, or for nationwide
+// closures only when state is empty
+func GetBusinessCalendar(state string) (*calendar.Calendar, error) {
+	ctx := context.Background()
+
+	var cal *calendar.Calendar
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		states := []string{}
+		if state != "" {
+			states = append(states, state)
+		}
+		closures, err := loadClosures(ctx, tx, states)
+		if err != nil {
+			return err
+		}
+		cal = calendar.New(closures, states...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cal, nil
+}
+
+// ListClosures returns admin-defined closures from the lookback window onwards
+func ListClosures() ([]models.BusinessClosure, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT id, closure_date, state, name, created_at, created_by
+		FROM business_closures
+		WHERE closure_date >= CURRENT_DATE - $1::integer
+		ORDER BY closure_date, state NULLS FIRST
+	`, closureLookback)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	closures := []models.BusinessClosure{}
+	for rows.Next() {
+		var c models.BusinessClosure
+		var date time.Time
+		if err := rows.Scan(&c.ID, &date, &c.State, &c.Name, &c.CreatedAt, &c.CreatedBy); err != nil {
+			return nil, err
+		}
+		c.Date = date.Format("2006-01-02")
+		closures = append(closures, c)
+	}
+
+	return closures, rows.Err()
+}
+
+// AddClosure records a day offices are closed, nationwide or for one state
+func AddClosure(userID int, input models.BusinessClosureInput) (*models.BusinessClosure, error) {
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(input.Date))
+	if err != nil {
+		return nil, ErrInvalidClosure
+	}
+	name := strings.TrimSpace(input.Name)
+	state := strings.ToUpper(strings.TrimSpace(input.State))
+	if name == "" || (state != "" && !closureStatePattern.MatchString(state)) {
+		return nil, ErrInvalidClosure
+	}
+
+	ctx := context.Background()
+
+	closure := &models.BusinessClosure{Date: date.Format("2006-01-02"), Name: name}
+	err = database.QueryRow(ctx, `
+		INSERT INTO business_closures (closure_date, state, name, created_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (closure_date, COALESCE(state, '')) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, state, created_at, created_by
+	`, date, nullStringValue(state), name, userID).Scan(
+		&closure.ID, &closure.State, &closure.CreatedAt, &closure.CreatedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return closure, nil
+}
+
+// DeleteClosure removes an admin-defined closure
+func DeleteClosure(closureID int) error {
+	ctx := context.Background()
+
+	tag, err := database.DB.Exec(ctx, `DELETE FROM business_closures WHERE id = $1`, closureID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrClosureNotFound
+	}
+	return nil
+}
+
+// NextBusinessDay finds the business day days after from in state's calendar.
+// With days = 0 it returns the start of the first business day on or after from.
+func NextBusinessDay(from time.Time, days int, state string) (*models.BusinessDayResult, error) {
+	state = strings.ToUpper(strings.TrimSpace(state))
+	if days < 0 || (state != "" && !closureStatePattern.MatchString(state)) {
+		return nil, ErrInvalidClosure
+	}
+
+	cal, err := GetBusinessCalendar(state)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BusinessDayResult{From: from, Days: days, State: state}
+	if days == 0 {
+		result.Date = cal.NextBusinessDay(from)
+	} else {
+		result.Date = cal.AddBusinessDays(from, days)
+	}
+
+	// Report weekday closures passed over so the UI can explain the date
+	for d := calendar.StartOfDay(from); d.Before(result.Date); d = d.AddDate(0, 0, 1) {
+		if h, closed := cal.Holiday(d); closed && d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+			result.SkippedDates = append(result.SkippedDates, d.Format("2006-01-02")+" "+h.Name)
+		}
+	}
+
+	return result, nil
+}
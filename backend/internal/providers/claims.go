@@ -0,0 +1,19 @@
+package providers
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// maxClaimAttempts bounds how often GetNextProvider retries after losing a claim race
+const maxClaimAttempts = 3
+
+// isClaimConflict reports whether err is the provider_claims primary key
+// rejecting a second active session for the same provider
+func isClaimConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == "23505" &&
+		pgErr.ConstraintName == "provider_claims_pkey"
+}
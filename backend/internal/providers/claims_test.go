@@ -0,0 +1,184 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/testdb"
+)
+
+// TestGetNextProviderConcurrentClaims has many agents claim, validate and
+// complete providers at once. Each provider must be handed out exactly once.
+func TestGetNextProviderConcurrentClaims(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+	ctx := context.Background()
+
+	const agents, count = 20, 300
+	for n := 0; n < count; n++ {
+		seedProvider(t, n, 1, 1, 0)
+	}
+	userIDs := make([]int, agents)
+	for i := range userIDs {
+		userIDs[i] = seedUser(t, fmt.Sprintf("agent-%d@example.com", i))
+	}
+
+	var mu sync.Mutex
+	claimedBy := make(map[int]int) // provider_id -> user_id
+	var wg sync.WaitGroup
+	for _, userID := range userIDs {
+		wg.Add(1)
+		go func(userID int) {
+			defer wg.Done()
+			for {
+				data, err := GetNextProvider(userID)
+				if err == ErrNoProvidersAvailable {
+					return
+				}
+				if err != nil {
+					t.Errorf("user %d: GetNextProvider: %v", userID, err)
+					return
+				}
+
+				providerID := data.Provider.ID
+				mu.Lock()
+				if previous, exists := claimedBy[providerID]; exists {
+					t.Errorf("provider %d assigned to user %d and user %d", providerID, previous, userID)
+				}
+				claimedBy[providerID] = userID
+				mu.Unlock()
+
+				if err := finishProvider(data, userID); err != nil {
+					t.Errorf("user %d, provider %d: %v", userID, providerID, err)
+					return
+				}
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	if len(claimedBy) != count {
+		t.Errorf("claimed %d providers, want %d", len(claimedBy), count)
+	}
+
+	var activeDuplicates int
+	err := database.QueryRow(ctx, `
+		SELECT COUNT(*) FROM (
+			SELECT provider_id FROM validation_sessions
+			WHERE status IN ('in_progress', 'on_hold')
+			GROUP BY provider_id HAVING COUNT(*) > 1
+		) d
+	`).Scan(&activeDuplicates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if activeDuplicates > 0 {
+		t.Errorf("%d providers have more than one active session", activeDuplicates)
+	}
+}
+
+// withoutCallingWindow lets seeded providers be claimed at any time of day
+func withoutCallingWindow(t testing.TB) {
+	t.Helper()
+	previous := config
+	c := *config
+	c.CallWindowEnabled = false
+	SetConfig(&c)
+	t.Cleanup(func() { SetConfig(previous) })
+}
+
+// finishProvider validates every address and phone and completes the session
+func finishProvider(data *models.ProviderValidationData, userID int) error {
+	var update models.ValidationUpdate
+	for _, addr := range data.Addresses {
+		update.AddressValidations = append(update.AddressValidations,
+			models.AddressValidation{AddressID: addr.ID, IsCorrect: true})
+	}
+	for _, phone := range data.Phones {
+		update.PhoneValidations = append(update.PhoneValidations,
+			models.PhoneValidation{PhoneID: phone.ID, IsCorrect: true})
+	}
+
+	sessionID := data.ValidationSession.ID
+	if _, err := UpdateValidation(sessionID, userID, update); err != nil {
+		return err
+	}
+	return CompleteValidation(sessionID, userID)
+}
+
+// TestReleasedPartialProviderCanBeReclaimed releases a provider with one of
+// two addresses and phones validated. Claiming it again must give an open
+// session that accepts the remaining validations.
+func TestReleasedPartialProviderCanBeReclaimed(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+
+	userID := seedUser(t, "agent@example.com")
+	providerID := seedProvider(t, 1, 2, 2, 0)
+
+	data := claimPartially(t, userID)
+	err := ReleaseSession(data.ValidationSession.ID, userID, models.ReleaseSessionRequest{ReasonCode: "other"})
+	if err != nil {
+		t.Fatalf("ReleaseSession: %v", err)
+	}
+
+	expectReclaimable(t, providerID, userID)
+}
+
+// claimPartially claims the next provider and validates its first address
+// and phone, leaving the rest open
+func claimPartially(t *testing.T, userID int) *models.ProviderValidationData {
+	t.Helper()
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	partial := models.ValidationUpdate{
+		AddressValidations: []models.AddressValidation{{AddressID: data.Addresses[0].ID, IsCorrect: true}},
+		PhoneValidations:   []models.PhoneValidation{{PhoneID: data.Phones[0].ID, IsCorrect: true}},
+	}
+	if _, err := UpdateValidation(data.ValidationSession.ID, userID, partial); err != nil {
+		t.Fatalf("UpdateValidation: %v", err)
+	}
+	return data
+}
+
+// expectReclaimable claims the next provider, which must be providerID, and
+// validates and completes it through the new session
+func expectReclaimable(t *testing.T, providerID, userID int) {
+	t.Helper()
+
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("re-claim: %v", err)
+	}
+	if data.Provider.ID != providerID {
+		t.Fatalf("re-claimed provider %d, want %d", data.Provider.ID, providerID)
+	}
+	sessionID := data.ValidationSession.ID
+	if got := sessionStatus(t, sessionID); got != "in_progress" {
+		t.Fatalf("re-claimed session status = %q, want in_progress", got)
+	}
+	if err := finishProvider(data, userID); err != nil {
+		t.Fatalf("finish re-claimed provider: %v", err)
+	}
+	if got := sessionStatus(t, sessionID); got != "completed" {
+		t.Errorf("session status after completion = %q, want completed", got)
+	}
+}
+
+// sessionStatus reads a session's stored status
+func sessionStatus(t testing.TB, sessionID int) string {
+	t.Helper()
+	var status string
+	err := database.QueryRow(context.Background(),
+		`SELECT status FROM validation_sessions WHERE id = $1`, sessionID).Scan(&status)
+	if err != nil {
+		t.Fatalf("read session %d: %v", sessionID, err)
+	}
+	return status
+}
@@ -0,0 +1,120 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds tunables for the provider validation workflow
+type Config struct {
+	SessionTTL           time.Duration
+	SessionReapInterval  time.Duration
+	CallbackGracePeriod  time.Duration
+	QueueAgingInterval   time.Duration // waiting this long raises a provider's priority by one
+	QueueAgingRefresh    time.Duration // how often queued priorities are re-aged
+	SkillRoutingFallback bool          // hand out any provider once an agent's skill queue is empty
+	CallWindowEnabled    bool          // only hand out providers inside local office hours
+	CallWindowStart      string        // local office opening time, HH:MM
+	CallWindowEnd        string        // local office closing time, HH:MM
+	CallWindowRefuse     bool          // refuse call attempts outside the window instead of warning
+	AutoDisposition      bool          // close providers as unreachable once every allowed attempt goes unanswered
+	ZipCheckRefuse       bool          // reject address corrections whose ZIP is in another state instead of warning
+	CallMaxDuration      time.Duration // live telephony calls with no hangup event after this long are expired
+}
+
+var config = defaultConfig()
+
+func defaultConfig() *Config {
+	return &Config{
+		SessionTTL:           30 * time.Minute,
+		SessionReapInterval:  time.Minute,
+		CallbackGracePeriod:  15 * time.Minute,
+		QueueAgingInterval:   24 * time.Hour,
+		QueueAgingRefresh:    5 * time.Minute,
+		SkillRoutingFallback: true,
+		CallWindowEnabled:    true,
+		CallWindowStart:      "08:00",
+		CallWindowEnd:        "17:00",
+		CallWindowRefuse:     false,
+		AutoDisposition:      true,
+		ZipCheckRefuse:       false,
+		CallMaxDuration:      2 * time.Hour,
+	}
+}
+
+// LoadConfig reads workflow settings from the environment, falling back to
+// defaults. It rejects settings the background workers cannot run with.
+func LoadConfig() (*Config, error) {
+	defaults := defaultConfig()
+	c := &Config{
+		SessionTTL:           getEnvAsDuration("SESSION_TTL", defaults.SessionTTL),
+		SessionReapInterval:  getEnvAsDuration("SESSION_REAP_INTERVAL", defaults.SessionReapInterval),
+		CallbackGracePeriod:  getEnvAsDuration("CALLBACK_GRACE_PERIOD", defaults.CallbackGracePeriod),
+		QueueAgingInterval:   getEnvAsDuration("QUEUE_AGING_INTERVAL", defaults.QueueAgingInterval),
+		QueueAgingRefresh:    getEnvAsDuration("QUEUE_AGING_REFRESH", defaults.QueueAgingRefresh),
+		SkillRoutingFallback: getEnvAsBool("SKILL_ROUTING_FALLBACK", defaults.SkillRoutingFallback),
+		CallWindowEnabled:    getEnvAsBool("CALL_WINDOW_ENABLED", defaults.CallWindowEnabled),
+		CallWindowStart:      getEnvAsClock("CALL_WINDOW_START", defaults.CallWindowStart),
+		CallWindowEnd:        getEnvAsClock("CALL_WINDOW_END", defaults.CallWindowEnd),
+		CallWindowRefuse:     getEnvAsBool("CALL_WINDOW_REFUSE", defaults.CallWindowRefuse),
+		AutoDisposition:      getEnvAsBool("AUTO_DISPOSITION_UNREACHABLE", defaults.AutoDisposition),
+		ZipCheckRefuse:       getEnvAsBool("ZIP_CHECK_REFUSE", defaults.ZipCheckRefuse),
+		CallMaxDuration:      getEnvAsDuration("CALL_MAX_DURATION", defaults.CallMaxDuration),
+	}
+
+	if c.SessionTTL <= 0 {
+		return nil, fmt.Errorf("SESSION_TTL must be positive, got %s", c.SessionTTL)
+	}
+	if c.SessionReapInterval <= 0 {
+		return nil, fmt.Errorf("SESSION_REAP_INTERVAL must be positive, got %s", c.SessionReapInterval)
+	}
+	if c.QueueAgingRefresh <= 0 {
+		return nil, fmt.Errorf("QUEUE_AGING_REFRESH must be positive, got %s", c.QueueAgingRefresh)
+	}
+	if c.CallMaxDuration <= 0 {
+		return nil, fmt.Errorf("CALL_MAX_DURATION must be positive, got %s", c.CallMaxDuration)
+	}
+	return c, nil
+}
+
+// SetConfig replaces the active workflow settings
+func SetConfig(c *Config) {
+	if c != nil {
+		config = c
+	}
+}
+
+// GetConfig returns the active workflow settings
+func GetConfig() *Config {
+	return config
+}
+
+// Helper functions for environment variables
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsClock(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		if _, err := time.Parse("15:04", value); err == nil {
+			return value
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,33 @@
+package providers
+
+import "testing"
+
+func TestLoadConfigRejectsNonPositiveIntervals(t *testing.T) {
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"SESSION_TTL", "0", true},
+		{"SESSION_TTL", "10m", false},
+		{"SESSION_REAP_INTERVAL", "0", true},
+		{"SESSION_REAP_INTERVAL", "-1m", true},
+		{"SESSION_REAP_INTERVAL", "30s", false},
+		{"QUEUE_AGING_REFRESH", "0s", true},
+		{"QUEUE_AGING_REFRESH", "-1m", true},
+		{"QUEUE_AGING_REFRESH", "1m", false},
+		{"CALL_MAX_DURATION", "0", true},
+		{"CALL_MAX_DURATION", "90m", false},
+		// Zero aging interval turns aging off rather than ticking
+		{"QUEUE_AGING_INTERVAL", "0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			_, err := LoadConfig()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/npi"
+)
+
+// GetProviderByNPI returns a provider's records and validation history. An
+// NPI that fails the check digit is refused with the npi package's error.
+func GetProviderByNPI(number string) (*models.ProviderDetail, error) {
+	if err := npi.Validate(number); err != nil {
+		return nil, err
+	}
+	return getProviderDetail(`SELECT id FROM providers WHERE npi = $1`, number)
+}
+
+// GetProviderByUUID is GetProviderByNPI for the provider's UUID
+func GetProviderByUUID(id uuid.UUID) (*models.ProviderDetail, error) {
+	return getProviderDetail(`SELECT id FROM providers WHERE uuid = $1`, id)
+}
+
+// getProviderDetail loads the provider that lookup, a query returning its id,
+// finds for key
+func getProviderDetail(lookup string, key interface{}) (*models.ProviderDetail, error) {
+	ctx := context.Background()
+
+	var detail *models.ProviderDetail
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var providerID int
+		if err := tx.QueryRow(ctx, lookup, key).Scan(&providerID); err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrProviderNotFound
+			}
+			return err
+		}
+
+		result := &models.ProviderDetail{}
+		if err := loadProvider(ctx, tx, providerID, &result.Provider); err != nil {
+			return err
+		}
+		err := tx.QueryRow(ctx, `
+			SELECT outcome, disposition_reason
+			FROM provider_validation_outcomes
+			WHERE provider_id = $1
+		`, providerID).Scan(&result.ValidationStatus, &result.DispositionReason)
+		if err != nil {
+			return err
+		}
+
+		if result.Addresses, err = getProviderAddresses(ctx, tx, providerID); err != nil {
+			return err
+		}
+		if result.Phones, err = getProviderPhones(ctx, tx, providerID); err != nil {
+			return err
+		}
+		result.Warnings = checkAreaCodes(result.Addresses, result.Phones)
+
+		if result.Sessions, err = getSessionHistory(ctx, tx, providerID); err != nil {
+			return err
+		}
+
+		detail = result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+// getSessionHistory returns every validation session of a provider with its
+// agent, newest first
+func getSessionHistory(ctx context.Context, tx pgx.Tx, providerID int) ([]models.SessionHistoryEntry, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+sessionColumns+`
+		FROM validation_sessions
+		WHERE provider_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.SessionHistoryEntry{}
+	for rows.Next() {
+		var entry models.SessionHistoryEntry
+		if err := scanSession(rows, &entry.ValidationSession); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// sessionColumns can't be joined unqualified, so agents are read separately
+	userIDs := make([]int, len(sessions))
+	for i, session := range sessions {
+		userIDs[i] = session.UserID
+	}
+	agentRows, err := tx.Query(ctx, `
+		SELECT id, email, first_name, last_name FROM users WHERE id = ANY($1)
+	`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer agentRows.Close()
+
+	agents := map[int]models.SessionHistoryEntry{}
+	for agentRows.Next() {
+		var id int
+		var agent models.SessionHistoryEntry
+		if err := agentRows.Scan(&id, &agent.AgentEmail, &agent.AgentFirstName, &agent.AgentLastName); err != nil {
+			return nil, err
+		}
+		agents[id] = agent
+	}
+	if err := agentRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		agent := agents[sessions[i].UserID]
+		sessions[i].AgentEmail = agent.AgentEmail
+		sessions[i].AgentFirstName = agent.AgentFirstName
+		sessions[i].AgentLastName = agent.AgentLastName
+	}
+	return sessions, nil
+}
@@ -0,0 +1,234 @@
+package providers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// DispositionUnreachable closes a provider that could not be reached by phone
+const DispositionUnreachable = "unreachable"
+
+// OutcomeInconclusiveUnreachable is how unreachable providers appear in exports
+const OutcomeInconclusiveUnreachable = "inconclusive-unreachable"
+
+// unreachableOutcomes are call outcomes that mean nobody could be reached
+var unreachableOutcomes = map[string]bool{
+	OutcomeNoAnswer:     true,
+	OutcomeBusy:         true,
+	OutcomeDisconnected: true,
+}
+
+// allAttemptsUnreachable reports whether at least one attempt was made and
+// every attempt ended without reaching anyone
+func allAttemptsUnreachable(attempts []models.CallAttemptRecord) bool {
+	if len(attempts) == 0 {
+		return false
+	}
+	for _, attempt := range attempts {
+		if !unreachableOutcomes[attempt.Status] {
+			return false
+		}
+	}
+	return true
+}
+
+// unreachableReason summarizes the outcomes, e.g. "no_answer x2, busy x1"
+func unreachableReason(attempts []models.CallAttemptRecord) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, attempt := range attempts {
+		if counts[attempt.Status] == 0 {
+			order = append(order, attempt.Status)
+		}
+		counts[attempt.Status]++
+	}
+
+	parts := make([]string, 0, len(order))
+	for _, outcome := range order {
+		parts = append(parts, outcome+" x"+strconv.Itoa(counts[outcome]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// disposeUnreachable closes the session, marks every unvalidated phone with
+// its last call outcome, and records the provider as unreachable so it leaves
+// the work queue
+func disposeUnreachable(ctx context.Context, tx pgx.Tx, sessionID, providerID, userID int,
+	attempts []models.CallAttemptRecord, automatic bool, notes string) error {
+	reason := unreachableReason(attempts)
+
+	_, err := tx.Exec(ctx, `
+		UPDATE validation_sessions
+		SET status = 'completed',
+		    completed_at = CURRENT_TIMESTAMP,
+		    locked_by = NULL,
+		    notes = COALESCE($1, notes),
+		    validation_results = validation_results || $2::jsonb,
+		    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`, nullStringValue(notes), map[string]interface{}{
+		"disposition":        DispositionUnreachable,
+		"disposition_reason": reason,
+		"disposed_by":        userID,
+		"disposed_at":        time.Now(),
+		"automatic":          automatic,
+	}, userID, sessionID)
+	if err != nil {
+		return err
+	}
+
+	// The last outcome seen on each phone becomes its reason
+	lastOutcome := make(map[int]string)
+	for _, attempt := range attempts {
+		if attempt.PhoneID != 0 {
+			lastOutcome[attempt.PhoneID] = attempt.Status
+		}
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM provider_phones WHERE provider_id = $1 AND is_correct IS NULL
+	`, providerID)
+	if err != nil {
+		return err
+	}
+	var phoneIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		phoneIDs = append(phoneIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, phoneID := range phoneIDs {
+		phoneReason, ok := lastOutcome[phoneID]
+		if !ok {
+			phoneReason = "not_dialed"
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE provider_phones
+			SET validation_notes = concat_ws(E'\n', validation_notes, 'Unreachable: ' || $1),
+			    validation_metadata = validation_metadata || jsonb_build_object(
+			        'unreachable', true,
+			        'unreachable_reason', $1::text,
+			        'unreachable_session_id', $2::integer
+			    ),
+			    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $4
+		`, phoneReason, sessionID, userID, phoneID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE providers
+		SET disposition = $1,
+		    disposition_reason = $2,
+		    disposition_at = CURRENT_TIMESTAMP,
+		    disposition_by = $3,
+		    disposition_session_id = $4,
+		    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+	`, DispositionUnreachable, reason, userID, sessionID, providerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "SELECT refresh_validation_stats()")
+	return err
+}
+
+// MarkUnreachable lets the agent close a session as unreachable before the
+// attempt policy is exhausted, provided every attempt so far went unanswered
+func MarkUnreachable(sessionID int, userID int, req models.UnreachableRequest) error {
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var sessionUserID, providerID int
+		var attempts []models.CallAttemptRecord
+		err := tx.QueryRow(ctx, `
+			SELECT user_id, provider_id, call_attempts
+			FROM validation_sessions
+			WHERE id = $1 AND status = 'in_progress'
+			FOR UPDATE
+		`, sessionID).Scan(&sessionUserID, &providerID, &attempts)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+			return err
+		}
+		if !allAttemptsUnreachable(attempts) {
+			return ErrNotUnreachable
+		}
+
+		return disposeUnreachable(ctx, tx, sessionID, providerID, userID, attempts, false, req.Notes)
+	})
+}
+
+// ClearDisposition returns a disposed provider to the work queue
+func ClearDisposition(providerID int, userID int) error {
+	ctx := context.Background()
+
+	tag, err := database.DB.Exec(ctx, `
+		UPDATE providers
+		SET disposition = NULL, disposition_reason = NULL, disposition_at = NULL,
+		    disposition_by = NULL, disposition_session_id = NULL,
+		    metadata = metadata || jsonb_build_object(
+		        'disposition_cleared_at', CURRENT_TIMESTAMP,
+		        'disposition_cleared_by', $2::integer
+		    ),
+		    updated_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND disposition IS NOT NULL
+	`, providerID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProviderNotFound
+	}
+	return nil
+}
+
+// StreamProviderOutcomes calls fn for each provider's current validation outcome
+func StreamProviderOutcomes(ctx context.Context, fn func(models.ProviderOutcome) error) error {
+	rows, err := database.Query(ctx, `
+		SELECT provider_id, npi, provider_name, outcome,
+		       disposition_reason, disposition_at, last_completed_at
+		FROM provider_validation_outcomes
+		ORDER BY provider_id
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var o models.ProviderOutcome
+		err := rows.Scan(&o.ProviderID, &o.NPI, &o.ProviderName, &o.Outcome,
+			&o.DispositionReason, &o.DispositionAt, &o.LastCompletedAt)
+		if err != nil {
+			return err
+		}
+		if err := fn(o); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,182 @@
+package providers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/phone"
+)
+
+// Flag types for globally flagged phone numbers
+var flagTypes = map[string]bool{
+	"invalid":      true,
+	"disconnected": true,
+	"wrong_number": true,
+	"fax":          true,
+	"do_not_call":  true,
+	"spam":         true,
+}
+
+// maxFlagPageSize bounds ListFlaggedPhones
+const maxFlagPageSize = 500
+
+// flagColumns matches scanFlag
+const flagColumns = `fp.id, fp.uuid, fp.phone, fp.flag_type, fp.flag_reason,
+	COALESCE(fp.flagged_count, 1), COALESCE(fp.severity, 1), fp.metadata,
+	fp.flagged_by, fp.resolved_by, fp.resolved_at, COALESCE(fp.is_active, true),
+	fp.created_at, fp.updated_at`
+
+func scanFlag(row pgx.Row, flag *models.FlaggedPhone) error {
+	return row.Scan(
+		&flag.ID, &flag.UUID, &flag.Phone, &flag.FlagType, &flag.FlagReason,
+		&flag.FlaggedCount, &flag.Severity, &flag.Metadata,
+		&flag.FlaggedBy, &flag.ResolvedBy, &flag.ResolvedAt, &flag.IsActive,
+		&flag.CreatedAt, &flag.UpdatedAt,
+	)
+}
+
+// phoneDigits keeps only the digits of a search term, so partial numbers
+// like "(217) 55" still match stored E.164 numbers
+func phoneDigits(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FlagPhone flags a number for every provider that lists it. Flagging a
+// number again counts the repeat, keeps the highest severity, takes the
+// latest type and reason, and reactivates a resolved flag.
+func FlagPhone(userID int, req models.FlagPhoneRequest) (*models.FlaggedPhone, error) {
+	number, err := phone.Parse(req.Phone)
+	if err != nil {
+		return nil, ErrInvalidPhone
+	}
+	flagType := strings.TrimSpace(req.FlagType)
+	if flagType == "" {
+		flagType = "invalid"
+	}
+	if !flagTypes[flagType] {
+		return nil, ErrInvalidFlag
+	}
+	severity := req.Severity
+	if severity == 0 {
+		severity = 1
+	}
+	if severity < 1 || severity > 5 {
+		return nil, ErrInvalidFlag
+	}
+	reason := strings.TrimSpace(req.Reason)
+
+	ctx := context.Background()
+
+	history := map[string]interface{}{
+		"flagged_by": userID,
+		"flagged_at": time.Now(),
+		"flag_type":  flagType,
+		"severity":   severity,
+		"reason":     reason,
+	}
+
+	var flag models.FlaggedPhone
+	err = scanFlag(database.QueryRow(ctx, `
+		INSERT INTO flagged_phones AS fp (phone, flag_type, flag_reason, severity, flagged_by, metadata)
+		VALUES ($1, $2, $3, $4, $5, jsonb_build_object('history', jsonb_build_array($6::jsonb)))
+		ON CONFLICT (phone) DO UPDATE SET
+			flag_type = EXCLUDED.flag_type,
+			flag_reason = COALESCE(EXCLUDED.flag_reason, fp.flag_reason),
+			severity = CASE WHEN fp.is_active THEN GREATEST(fp.severity, EXCLUDED.severity) ELSE EXCLUDED.severity END,
+			flagged_count = COALESCE(fp.flagged_count, 1) + 1,
+			is_active = true,
+			resolved_by = NULL,
+			resolved_at = NULL,
+			metadata = jsonb_set(COALESCE(fp.metadata, '{}'::jsonb), '{history}',
+				COALESCE(fp.metadata->'history', '[]'::jsonb) || jsonb_build_array($6::jsonb))
+		RETURNING `+flagColumns+`
+	`, number.E164(), flagType, nullStringValue(reason), severity, userID, history), &flag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flag, nil
+}
+
+// ResolveFlag clears an active flag; the record and its history are kept
+func ResolveFlag(flagID int, userID int, req models.ResolveFlagRequest) error {
+	ctx := context.Background()
+
+	tag, err := database.DB.Exec(ctx, `
+		UPDATE flagged_phones
+		SET is_active = false,
+		    resolved_by = $2,
+		    resolved_at = CURRENT_TIMESTAMP,
+		    metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('resolution_notes', $3::text)
+		WHERE id = $1 AND is_active
+	`, flagID, userID, req.Notes)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrFlagNotFound
+	}
+	return nil
+}
+
+// ListFlaggedPhones searches flags by number or reason, most severe first.
+// Resolved flags are only included when asked for.
+func ListFlaggedPhones(filter models.FlaggedPhoneFilter) ([]models.FlaggedPhone, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > maxFlagPageSize || filter.Offset < 0 || filter.MinSeverity < 0 || filter.MinSeverity > 5 {
+		return nil, ErrInvalidFlag
+	}
+	if filter.FlagType != "" && !flagTypes[filter.FlagType] {
+		return nil, ErrInvalidFlag
+	}
+
+	query := strings.TrimSpace(filter.Query)
+	// A full number matches exactly; anything else narrows by its digits
+	exact, _ := phone.Normalize(query)
+	digits := phoneDigits(query)
+	escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT `+flagColumns+`
+		FROM flagged_phones fp
+		WHERE ($1::text = ''
+		       OR fp.phone = $9
+		       OR ($9::text = '' AND $2::text <> '' AND fp.phone LIKE '%' || $2 || '%')
+		       OR fp.flag_reason ILIKE '%' || $3 || '%')
+		  AND ($4::text = '' OR fp.flag_type = $4)
+		  AND COALESCE(fp.severity, 1) >= $5
+		  AND ($6::boolean OR fp.is_active)
+		ORDER BY fp.is_active DESC, fp.severity DESC, fp.updated_at DESC, fp.id
+		LIMIT $7 OFFSET $8
+	`, query, digits, escape.Replace(query), filter.FlagType, filter.MinSeverity,
+		filter.IncludeResolved, filter.Limit, filter.Offset, exact)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	flags := []models.FlaggedPhone{}
+	for rows.Next() {
+		var flag models.FlaggedPhone
+		if err := scanFlag(rows, &flag); err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, rows.Err()
+}
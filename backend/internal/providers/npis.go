@@ -0,0 +1,69 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/npi"
+)
+
+// ListInvalidNPIs reports stored providers whose NPI fails the check digit,
+// active providers first
+func ListInvalidNPIs(limit int) ([]models.InvalidNPI, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT id, uuid, npi, provider_name, COALESCE(is_active, false)
+		FROM providers
+		WHERE NOT npi_is_valid(npi)
+		ORDER BY is_active DESC, id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invalid := []models.InvalidNPI{}
+	for rows.Next() {
+		var p models.InvalidNPI
+		if err := rows.Scan(&p.ProviderID, &p.UUID, &p.NPI, &p.ProviderName, &p.IsActive); err != nil {
+			return nil, err
+		}
+		if err := npi.Validate(p.NPI); err != nil {
+			p.Reason = err.Error()
+		}
+		invalid = append(invalid, p)
+	}
+
+	return invalid, rows.Err()
+}
+
+// ListQuarantinedNPIs returns the import rows the loader set aside for their
+// NPI, newest first
+func ListQuarantinedNPIs(limit int) ([]models.QuarantinedNPI, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT id, npi, provider_name, reason, record, row_number, created_at
+		FROM npi_quarantine
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quarantined := []models.QuarantinedNPI{}
+	for rows.Next() {
+		var q models.QuarantinedNPI
+		if err := rows.Scan(&q.ID, &q.NPI, &q.ProviderName, &q.Reason, &q.Record, &q.RowNumber, &q.CreatedAt); err != nil {
+			return nil, err
+		}
+		quarantined = append(quarantined, q)
+	}
+
+	return quarantined, rows.Err()
+}
@@ -0,0 +1,112 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/phone"
+)
+
+// areaCodeMismatch looks up a stored number's area code and reports whether
+// it is assigned outside every one of states. Toll-free numbers, unknown area
+// codes and providers without an address state are never mismatched.
+func areaCodeMismatch(number string, states []string) (phone.AreaCode, bool) {
+	parsed, err := phone.Parse(number)
+	if err != nil {
+		return phone.AreaCode{}, false
+	}
+	area, ok := parsed.AreaCode()
+	if !ok || !area.Geographic() || len(states) == 0 {
+		return area, false
+	}
+	for _, state := range states {
+		if area.Serves(state) {
+			return area, false
+		}
+	}
+	return area, true
+}
+
+// addressStates returns the distinct states of a provider's addresses,
+// preferring corrected states
+func addressStates(addresses []models.ProviderAddress) []string {
+	var states []string
+	seen := map[string]bool{}
+	for _, addr := range addresses {
+		state := addr.State.String
+		if addr.CorrectedState.Valid && addr.CorrectedState.String != "" {
+			state = addr.CorrectedState.String
+		}
+		if state != "" && !seen[state] {
+			seen[state] = true
+			states = append(states, state)
+		}
+	}
+	return states
+}
+
+// checkAreaCodes sets the area code fields of each phone and returns a warning
+// for every phone whose area code does not match the provider's addresses
+func checkAreaCodes(addresses []models.ProviderAddress, phones []models.ProviderPhone) []string {
+	states := addressStates(addresses)
+
+	var warnings []string
+	for i := range phones {
+		p := &phones[i]
+		number := p.Phone
+		if p.CorrectedPhone.Valid {
+			number = p.CorrectedPhone.String
+		}
+		area, mismatch := areaCodeMismatch(number, states)
+		p.AreaCodeRegion = area.Region()
+		p.AreaCodeMismatch = mismatch
+		if mismatch {
+			warnings = append(warnings, fmt.Sprintf("%s: area code %s is in %s, but the provider's addresses are in %s",
+				phone.Format(number), area.Code, area.Region(), strings.Join(states, ", ")))
+		}
+	}
+	return warnings
+}
+
+// ListAreaCodeMismatches reports active providers' phones whose area code is
+// outside every state the provider has an address in
+func ListAreaCodeMismatches(limit int) ([]models.AreaCodeMismatch, error) {
+	ctx := context.Background()
+
+	// The area code reference lives in Go, so every phone is read and filtered here
+	rows, err := database.Query(ctx, `
+		SELECT pp.id, p.id, p.npi, p.provider_name, COALESCE(pp.corrected_phone, pp.phone),
+		       COALESCE(array_agg(DISTINCT COALESCE(NULLIF(pa.corrected_state, ''), pa.state))
+		                FILTER (WHERE COALESCE(NULLIF(pa.corrected_state, ''), pa.state) IS NOT NULL), '{}')
+		FROM provider_phones pp
+		JOIN providers p ON p.id = pp.provider_id
+		LEFT JOIN provider_addresses pa ON pa.provider_id = p.id
+		WHERE p.is_active = true
+		GROUP BY pp.id, p.id, p.npi, p.provider_name
+		ORDER BY p.id, pp.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mismatches := []models.AreaCodeMismatch{}
+	for rows.Next() && len(mismatches) < limit {
+		var m models.AreaCodeMismatch
+		if err := rows.Scan(&m.PhoneID, &m.ProviderID, &m.NPI, &m.ProviderName, &m.Phone, &m.AddressStates); err != nil {
+			return nil, err
+		}
+		area, mismatch := areaCodeMismatch(m.Phone, m.AddressStates)
+		if !mismatch {
+			continue
+		}
+		m.AreaCode = area.Code
+		m.AreaCodeRegion = area.Region()
+		mismatches = append(mismatches, m)
+	}
+
+	return mismatches, rows.Err()
+}
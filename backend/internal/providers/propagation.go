@@ -0,0 +1,345 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// phoneTargetBusy is true when the target's provider has another session open
+// or parked on hold; $2 is the current session
+const phoneTargetBusy = `EXISTS (
+	SELECT 1 FROM validation_sessions vs
+	WHERE vs.provider_id = target.provider_id AND vs.status IN ('in_progress', 'on_hold') AND vs.id <> $2
+)`
+
+// propagatePhoneValidation copies a validated phone's result, including any
+// corrected number and extension, to every unvalidated phone with the same
+// number, recording where it came from, and returns how many changed. Phones
+// of providers with another open or on-hold session are left alone so no
+// session's data changes underneath its agent.
+func propagatePhoneValidation(ctx context.Context, tx pgx.Tx, sessionID, userID, providerID, phoneID int) (int, error) {
+	tag, err := tx.Exec(ctx, `
+		UPDATE provider_phones target
+		SET is_correct = src.is_correct,
+		    corrected_phone = src.corrected_phone,
+		    validation_metadata = (COALESCE(target.validation_metadata, '{}'::jsonb) - 'corrected_extension')
+		        || jsonb_strip_nulls(jsonb_build_object('corrected_extension', src.validation_metadata->'corrected_extension'))
+		        || jsonb_build_object(
+		            'validated_at', CURRENT_TIMESTAMP,
+		            'validation_type', 'propagated',
+		            'user_id', $3::integer,
+		            'propagated_from', jsonb_build_object(
+		                'phone_id', src.id,
+		                'provider_id', src.provider_id,
+		                'session_id', $2::integer
+		            )
+		        ),
+		    validated_by = $3, validated_at = CURRENT_TIMESTAMP,
+		    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		FROM provider_phones src
+		WHERE src.id = $1
+		  AND src.provider_id = $4
+		  AND src.is_correct IS NOT NULL
+		  AND normalize_phone(target.phone) = normalize_phone(src.phone)
+		  AND target.id <> src.id
+		  AND target.is_correct IS NULL
+		  AND NOT `+phoneTargetBusy+`
+	`, phoneID, sessionID, userID, providerID)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// PreviewPhonePropagation reports how many phone records would take this
+// phone's validation result if it were propagated
+func PreviewPhonePropagation(sessionID int, userID int, phoneID int) (*models.PropagationPreview, error) {
+	ctx := context.Background()
+
+	var preview *models.PropagationPreview
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var sessionUserID, providerID int
+		err := tx.QueryRow(ctx, `
+			SELECT user_id, provider_id FROM validation_sessions WHERE id = $1
+		`, sessionID).Scan(&sessionUserID, &providerID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+			return err
+		}
+
+		preview = &models.PropagationPreview{PhoneID: phoneID}
+		err = tx.QueryRow(ctx, `
+			SELECT COALESCE(normalize_phone(phone), '') FROM provider_phones WHERE id = $1 AND provider_id = $2
+		`, phoneID, providerID).Scan(&preview.NormalizedPhone)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrPhoneNotFound
+			}
+			return err
+		}
+
+		return tx.QueryRow(ctx, `
+			SELECT COUNT(*) FILTER (WHERE NOT busy),
+			       COUNT(DISTINCT provider_id) FILTER (WHERE NOT busy),
+			       COUNT(*) FILTER (WHERE busy)
+			FROM (
+			    SELECT target.provider_id, `+phoneTargetBusy+` AS busy
+			    FROM provider_phones target, provider_phones src
+			    WHERE src.id = $1
+			      AND normalize_phone(target.phone) = normalize_phone(src.phone)
+			      AND target.id <> src.id
+			      AND target.is_correct IS NULL
+			) matches
+		`, phoneID, sessionID).Scan(&preview.Records, &preview.Providers, &preview.SkippedInSession)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// addressMatches selects unvalidated addresses with the same address_key as
+// source address $1, limited to the source provider's group or
+// GNPI when $3 is set. Callers add addressTargetBusy as needed.
+const addressMatches = `
+	FROM provider_addresses target
+	JOIN provider_addresses src ON src.id = $1
+	JOIN providers sp ON sp.id = src.provider_id
+	JOIN providers tp ON tp.id = target.provider_id
+	WHERE target.address_key = src.address_key
+	  AND target.id <> src.id
+	  AND target.is_correct IS NULL
+	  AND (NOT $3::boolean
+	       OR (sp.provider_group IS NOT NULL AND tp.provider_group = sp.provider_group)
+	       OR (sp.gnpi IS NOT NULL AND tp.gnpi = sp.gnpi))`
+
+// addressTargetBusy is true when another agent has the target's provider open; $2 is the current session
+const addressTargetBusy = `EXISTS (
+	SELECT 1 FROM validation_sessions vs
+	WHERE vs.provider_id = target.provider_id AND vs.status = 'in_progress' AND vs.id <> $2
+)`
+
+// propagateAddressValidation copies a validated address's result to matching
+// unvalidated addresses and returns how many changed. The previous state of
+// each is kept so a supervisor can undo the propagation.
+func propagateAddressValidation(ctx context.Context, tx pgx.Tx, sessionID, userID, providerID, addressID int, withinGroup bool) (int, error) {
+	var propagationID int
+	err := tx.QueryRow(ctx, `
+		INSERT INTO address_propagations (source_address_id, source_provider_id, source_session_id, within_group, propagated_by)
+		SELECT id, provider_id, $2::integer, $3::boolean, $4::integer
+		FROM provider_addresses
+		WHERE id = $1 AND provider_id = $5 AND is_correct IS NOT NULL
+		RETURNING id
+	`, addressID, sessionID, withinGroup, userID, providerID).Scan(&propagationID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO address_propagation_targets (propagation_id, address_id, previous)
+		SELECT $4::integer, target.id, jsonb_build_object(
+		    'is_correct', target.is_correct,
+		    'corrected_address1', target.corrected_address1,
+		    'corrected_address2', target.corrected_address2,
+		    'corrected_city', target.corrected_city,
+		    'corrected_state', target.corrected_state,
+		    'corrected_zip', target.corrected_zip,
+		    'validation_metadata', target.validation_metadata,
+		    'validated_by', target.validated_by,
+		    'validated_at', target.validated_at
+		)
+	`+addressMatches+`
+		  AND NOT `+addressTargetBusy+`
+	`, addressID, sessionID, withinGroup, propagationID)
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE provider_addresses target
+		SET is_correct = src.is_correct,
+		    corrected_address1 = src.corrected_address1,
+		    corrected_address2 = src.corrected_address2,
+		    corrected_city = src.corrected_city,
+		    corrected_state = src.corrected_state,
+		    corrected_zip = src.corrected_zip,
+		    validation_metadata = COALESCE(target.validation_metadata, '{}'::jsonb) || jsonb_build_object(
+		        'validated_at', CURRENT_TIMESTAMP,
+		        'validation_type', 'propagated',
+		        'user_id', $3::integer,
+		        'propagation_id', $1::integer,
+		        'propagated_from', jsonb_build_object(
+		            'address_id', src.id,
+		            'provider_id', src.provider_id,
+		            'session_id', $2::integer
+		        )
+		    ),
+		    validated_by = $3, validated_at = CURRENT_TIMESTAMP,
+		    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		FROM address_propagation_targets apt, provider_addresses src
+		WHERE apt.propagation_id = $1
+		  AND target.id = apt.address_id
+		  AND src.id = $4
+	`, propagationID, sessionID, userID, addressID)
+	if err != nil {
+		return 0, err
+	}
+	count := int(tag.RowsAffected())
+
+	// Nothing matched: don't leave an empty propagation to undo
+	if count == 0 {
+		_, err = tx.Exec(ctx, `DELETE FROM address_propagations WHERE id = $1`, propagationID)
+		return 0, err
+	}
+	_, err = tx.Exec(ctx, `UPDATE address_propagations SET target_count = $1 WHERE id = $2`, count, propagationID)
+	return count, err
+}
+
+// PreviewAddressPropagation reports how many address records would take this
+// address's validation result if it were propagated
+func PreviewAddressPropagation(sessionID int, userID int, addressID int, withinGroup bool) (*models.AddressPropagationPreview, error) {
+	ctx := context.Background()
+
+	var preview *models.AddressPropagationPreview
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var sessionUserID, providerID int
+		err := tx.QueryRow(ctx, `
+			SELECT user_id, provider_id FROM validation_sessions WHERE id = $1
+		`, sessionID).Scan(&sessionUserID, &providerID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+			return err
+		}
+
+		preview = &models.AddressPropagationPreview{AddressID: addressID, WithinGroup: withinGroup}
+		err = tx.QueryRow(ctx, `
+			SELECT COALESCE(address_key, '')
+			FROM provider_addresses WHERE id = $1 AND provider_id = $2
+		`, addressID, providerID).Scan(&preview.NormalizedAddress)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrAddressNotFound
+			}
+			return err
+		}
+
+		return tx.QueryRow(ctx, `
+			SELECT COUNT(*) FILTER (WHERE NOT busy),
+			       COUNT(DISTINCT provider_id) FILTER (WHERE NOT busy),
+			       COUNT(*) FILTER (WHERE busy)
+			FROM (
+			    SELECT target.provider_id, `+addressTargetBusy+` AS busy
+			    `+addressMatches+`
+			) matches
+		`, addressID, sessionID, withinGroup).Scan(&preview.Records, &preview.Providers, &preview.SkippedInSession)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// ListAddressPropagations returns recent address propagations, newest first
+func ListAddressPropagations(limit int) ([]models.AddressPropagation, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT id, source_address_id, source_provider_id, source_session_id, within_group,
+		       target_count, propagated_by, created_at, undone_by, undone_at, restored_count
+		FROM address_propagations
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	propagations := []models.AddressPropagation{}
+	for rows.Next() {
+		var p models.AddressPropagation
+		err := rows.Scan(&p.ID, &p.SourceAddressID, &p.SourceProviderID, &p.SourceSessionID, &p.WithinGroup,
+			&p.TargetCount, &p.PropagatedBy, &p.CreatedAt, &p.UndoneBy, &p.UndoneAt, &p.RestoredCount)
+		if err != nil {
+			return nil, err
+		}
+		propagations = append(propagations, p)
+	}
+
+	return propagations, rows.Err()
+}
+
+// UndoAddressPropagation restores the addresses a propagation changed.
+// Addresses validated again since then keep their newer result.
+func UndoAddressPropagation(propagationID int, userID int) (int, error) {
+	ctx := context.Background()
+
+	restored := 0
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var undone bool
+		err := tx.QueryRow(ctx, `
+			SELECT undone_at IS NOT NULL FROM address_propagations WHERE id = $1 FOR UPDATE
+		`, propagationID).Scan(&undone)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrPropagationNotFound
+			}
+			return err
+		}
+		if undone {
+			return ErrPropagationUndone
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE provider_addresses a
+			SET is_correct = (apt.previous->>'is_correct')::boolean,
+			    corrected_address1 = apt.previous->>'corrected_address1',
+			    corrected_address2 = apt.previous->>'corrected_address2',
+			    corrected_city = apt.previous->>'corrected_city',
+			    corrected_state = apt.previous->>'corrected_state',
+			    corrected_zip = apt.previous->>'corrected_zip',
+			    validation_metadata = COALESCE(apt.previous->'validation_metadata', '{}'::jsonb),
+			    validated_by = (apt.previous->>'validated_by')::integer,
+			    validated_at = (apt.previous->>'validated_at')::timestamptz,
+			    updated_by = $2, updated_at = CURRENT_TIMESTAMP
+			FROM address_propagation_targets apt
+			WHERE apt.propagation_id = $1
+			  AND a.id = apt.address_id
+			  AND a.validation_metadata->>'propagation_id' = $1::text
+		`, propagationID, userID)
+		if err != nil {
+			return err
+		}
+		restored = int(tag.RowsAffected())
+
+		_, err = tx.Exec(ctx, `
+			UPDATE address_propagations
+			SET undone_by = $2, undone_at = CURRENT_TIMESTAMP, restored_count = $3
+			WHERE id = $1
+		`, propagationID, userID, restored)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return restored, nil
+}
@@ -0,0 +1,73 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/testdb"
+)
+
+func TestPhonePropagationCopiesExtensionAndSkipsHeldProviders(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+	ctx := context.Background()
+
+	userID := seedUser(t, "agent@example.com")
+	otherID := seedUser(t, "other@example.com")
+	providers := []int{seedProvider(t, 1, 1, 1, 0), seedProvider(t, 2, 1, 1, 0), seedProvider(t, 3, 1, 1, 0)}
+	err := database.Exec(ctx, `UPDATE provider_phones SET phone = '+12175559876' WHERE provider_id = ANY($1)`, providers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The third provider is parked on hold by another agent
+	held := providers[2]
+	err = database.Exec(ctx, `
+		INSERT INTO validation_sessions (provider_id, user_id, status, callback_at)
+		VALUES ($1, $2, 'on_hold', CURRENT_TIMESTAMP + INTERVAL '1 day')
+	`, held, otherID)
+	if err != nil {
+		t.Fatalf("seed held session: %v", err)
+	}
+
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	free := providers[0]
+	if data.Provider.ID == free {
+		free = providers[1]
+	}
+
+	_, err = UpdateValidation(data.ValidationSession.ID, userID, models.ValidationUpdate{
+		PhoneValidations: []models.PhoneValidation{{
+			PhoneID: data.Phones[0].ID, IsCorrect: false, CorrectedPhone: "217-555-4321 x12", Propagate: true,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("UpdateValidation: %v", err)
+	}
+
+	phoneOf := func(providerID int) (isCorrect *bool, corrected *string, extension *string) {
+		t.Helper()
+		err := database.QueryRow(ctx, `
+			SELECT is_correct, corrected_phone, validation_metadata->>'corrected_extension'
+			FROM provider_phones WHERE provider_id = $1
+		`, providerID).Scan(&isCorrect, &corrected, &extension)
+		if err != nil {
+			t.Fatalf("read phone of provider %d: %v", providerID, err)
+		}
+		return
+	}
+
+	isCorrect, corrected, extension := phoneOf(free)
+	if isCorrect == nil || *isCorrect || corrected == nil || *corrected != "+12175554321" ||
+		extension == nil || *extension != "12" {
+		t.Errorf("free provider's phone = is_correct %v, corrected %v, extension %v; want false, +12175554321, 12",
+			isCorrect, corrected, extension)
+	}
+	if isCorrect, corrected, _ := phoneOf(held); isCorrect != nil || corrected != nil {
+		t.Errorf("held provider's phone changed to is_correct %v, corrected %v", isCorrect, corrected)
+	}
+}
@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// agingSeconds is the configured queue aging interval as passed to provider_effective_priority
+func agingSeconds() int {
+	return int(config.QueueAgingInterval.Seconds())
+}
+
+// SetProviderPriority updates the base priority and optional deadline of a provider
+func SetProviderPriority(providerID int, userID int, update models.ProviderPriorityUpdate) error {
+	if update.Priority < 1 || update.Priority > 10 {
+		return ErrInvalidPriority
+	}
+
+	ctx := context.Background()
+
+	tag, err := database.DB.Exec(ctx, `
+		UPDATE providers
+		SET priority = $1, due_date = $2,
+		    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`, update.Priority, update.DueDate, userID, providerID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrProviderNotFound
+	}
+
+	return nil
+}
+
+// GetQueuePreview lists the providers that will be handed out next, in claim order
+func GetQueuePreview(limit int) ([]models.QueueItem, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT wq.provider_id, p.npi, p.provider_name, wq.base_priority,
+		       wq.priority, wq.due_date, wq.queued_at
+		FROM work_queue wq
+		JOIN providers p ON p.id = wq.provider_id
+		ORDER BY wq.priority DESC, wq.due_date ASC NULLS LAST, wq.queued_at, wq.provider_id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.QueueItem{}
+	for rows.Next() {
+		var item models.QueueItem
+		err := rows.Scan(
+			&item.ProviderID, &item.NPI, &item.ProviderName, &item.Priority,
+			&item.EffectivePriority, &item.DueDate, &item.QueuedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// AgeWorkQueue raises the priority of queued providers by one step per aging
+// interval they have waited. Claims read the stored priority so they stay index-ordered.
+func AgeWorkQueue(ctx context.Context) (int, error) {
+	tag, err := database.DB.Exec(ctx, `
+		UPDATE work_queue
+		SET priority = provider_effective_priority(base_priority, queued_at, $1::integer)
+		WHERE priority < 10
+		  AND priority <> provider_effective_priority(base_priority, queued_at, $1::integer)
+	`, agingSeconds())
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// RunQueueAging periodically applies queue aging until ctx is cancelled
+func RunQueueAging(ctx context.Context) {
+	ticker := time.NewTicker(config.QueueAgingRefresh)
+	defer ticker.Stop()
+
+	for {
+		if _, err := AgeWorkQueue(ctx); err != nil {
+			log.Printf("Queue aging: failed to update priorities: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
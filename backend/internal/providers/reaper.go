@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/user/auth-app/internal/database"
+)
+
+// SessionExpiryReason is recorded in validation_results when a session is reaped
+const SessionExpiryReason = "lock_expired"
+
+// ExpireStaleSessions cancels in-progress sessions whose lock has not been
+// touched within ttl, returning their providers to the work pool
+func ExpireStaleSessions(ctx context.Context, ttl time.Duration) (int, error) {
+	rows, err := database.Query(ctx, `
+		UPDATE validation_sessions
+		SET status = 'cancelled',
+		    locked_by = NULL,
+		    validation_results = validation_results || jsonb_build_object(
+		        'expired_at', CURRENT_TIMESTAMP,
+		        'expiry_reason', $2::text,
+		        'last_activity_at', GREATEST(locked_at, updated_at),
+		        'ttl_seconds', $1::integer
+		    )
+		WHERE status = 'in_progress'
+		  AND GREATEST(locked_at, updated_at) < CURRENT_TIMESTAMP - $1::integer * INTERVAL '1 second'
+		RETURNING id, provider_id, user_id
+	`, int(ttl.Seconds()), SessionExpiryReason)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	expired := 0
+	for rows.Next() {
+		var sessionID, providerID, userID int
+		if err := rows.Scan(&sessionID, &providerID, &userID); err != nil {
+			return expired, err
+		}
+		log.Printf("Expired stale session %d (provider %d, user %d)", sessionID, providerID, userID)
+		expired++
+	}
+
+	return expired, rows.Err()
+}
+
+// RunSessionReaper periodically expires stale sessions until ctx is cancelled
+func RunSessionReaper(ctx context.Context) {
+	ticker := time.NewTicker(config.SessionReapInterval)
+	defer ticker.Stop()
+
+	log.Printf("Session reaper started (ttl %s, interval %s)", config.SessionTTL, config.SessionReapInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := ExpireStaleSessions(ctx, config.SessionTTL); err != nil {
+				log.Printf("Session reaper: failed to expire stale sessions: %v", err)
+			}
+			if _, err := ExpireStaleCalls(ctx, config.CallMaxDuration); err != nil {
+				log.Printf("Session reaper: failed to expire stale calls: %v", err)
+			}
+		}
+	}
+}
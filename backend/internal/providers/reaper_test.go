@@ -0,0 +1,34 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/user/auth-app/internal/testdb"
+)
+
+// TestReapedPartialProviderCanBeReclaimed expires an abandoned session with
+// some validations saved. The provider must come back as an open session.
+func TestReapedPartialProviderCanBeReclaimed(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+
+	userID := seedUser(t, "agent@example.com")
+	providerID := seedProvider(t, 1, 2, 2, 0)
+
+	sessionID := claimPartially(t, userID).ValidationSession.ID
+
+	// With no TTL every session last touched before the reap is stale
+	expired, err := ExpireStaleSessions(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ExpireStaleSessions: %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("expired %d sessions, want 1", expired)
+	}
+	if got := sessionStatus(t, sessionID); got != "cancelled" {
+		t.Fatalf("reaped session status = %q, want cancelled", got)
+	}
+
+	expectReclaimable(t, providerID, userID)
+}
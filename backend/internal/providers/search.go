@@ -0,0 +1,145 @@
+package providers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/user/auth-app/internal/address"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/npi"
+	"github.com/user/auth-app/internal/phone"
+)
+
+// maxSearchPageSize bounds SearchProviders
+const maxSearchPageSize = 100
+
+// validationStatuses are the outcomes reported by provider_validation_outcomes
+var validationStatuses = map[string]bool{
+	"pending":                      true,
+	"in-progress":                  true,
+	"corrected":                    true,
+	"validated":                    true,
+	OutcomeInconclusiveUnreachable: true,
+}
+
+// searchConditions is shared by the page and count queries. $1 is the full
+// text query, $2 the same escaped for LIKE and $3 its E.164 form when it is a
+// phone number.
+const searchConditions = `
+	WHERE ($1::text = ''
+	       OR p.search_vector @@ plainto_tsquery('english', $1)
+	       OR p.npi LIKE '%' || $2 || '%'
+	       OR p.provider_name ILIKE '%' || $2 || '%'
+	       OR ($3::text <> '' AND EXISTS (
+	              SELECT 1 FROM provider_phones pp
+	              WHERE pp.provider_id = p.id
+	                AND (normalize_phone(pp.phone) = $3 OR normalize_phone(pp.corrected_phone) = $3))))
+	  AND ($4::text = '' OR p.npi LIKE $4 || '%')
+	  AND ($5::text = '' OR p.specialty ILIKE $5)
+	  AND ($6::text = '' OR EXISTS (
+	          SELECT 1 FROM provider_addresses pa
+	          WHERE pa.provider_id = p.id
+	            AND COALESCE(NULLIF(pa.corrected_state, ''), pa.state) = $6))
+	  AND ($7::text = '' OR p.provider_group ILIKE '%' || $7 || '%' OR p.gnpi = $7)
+	  AND ($8::text = '' OR o.outcome = $8)
+	  AND ($9::boolean IS NULL OR p.is_active = $9)
+`
+
+// SearchProviders finds providers by full text (name, specialty, group, NPI
+// or a phone number) and filters, best matches first, one page at a time
+func SearchProviders(filter models.ProviderSearchFilter) (*models.ProviderSearchPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 25
+	}
+	if filter.Limit > maxSearchPageSize || filter.Offset < 0 {
+		return nil, ErrInvalidSearch
+	}
+	if filter.ValidationStatus != "" && !validationStatuses[filter.ValidationStatus] {
+		return nil, ErrInvalidSearch
+	}
+	npiPrefix := strings.TrimSpace(filter.NPIPrefix)
+	if len(npiPrefix) > 10 || strings.Trim(npiPrefix, "0123456789") != "" {
+		return nil, ErrInvalidSearch
+	}
+	// A complete NPI has to pass the check digit
+	if len(npiPrefix) == 10 {
+		if err := npi.Validate(npiPrefix); err != nil {
+			return nil, err
+		}
+	}
+	state := ""
+	if strings.TrimSpace(filter.State) != "" {
+		var err error
+		if state, err = address.State(filter.State); err != nil {
+			return nil, ErrInvalidSearch
+		}
+	}
+
+	query := strings.TrimSpace(filter.Query)
+	// A term that is a phone number also finds providers listing it, however it was typed
+	phoneTerm, _ := phone.Normalize(query)
+	escape := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	args := []interface{}{
+		query, escape.Replace(query), phoneTerm, npiPrefix,
+		escape.Replace(strings.TrimSpace(filter.Specialty)), state,
+		escape.Replace(strings.TrimSpace(filter.Group)), filter.ValidationStatus, filter.Active,
+	}
+
+	ctx := context.Background()
+
+	page := &models.ProviderSearchPage{
+		Results: []models.ProviderSearchResult{},
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}
+	err := database.QueryRow(ctx, `
+		SELECT COUNT(*)
+		FROM providers p
+		JOIN provider_validation_outcomes o ON o.provider_id = p.id
+	`+searchConditions, args...).Scan(&page.Total)
+	if err != nil {
+		return nil, err
+	}
+	if page.Total == 0 {
+		return page, nil
+	}
+
+	// An exact NPI outranks any text match
+	rows, err := database.Query(ctx, `
+		SELECT p.id, p.uuid, p.npi, p.provider_name,
+		       COALESCE(p.specialty, ''), COALESCE(p.provider_group, ''),
+		       ARRAY(SELECT DISTINCT COALESCE(NULLIF(pa.corrected_state, ''), pa.state)
+		             FROM provider_addresses pa
+		             WHERE pa.provider_id = p.id
+		               AND COALESCE(NULLIF(pa.corrected_state, ''), pa.state) IS NOT NULL
+		             ORDER BY 1),
+		       o.outcome, COALESCE(p.is_active, false),
+		       (CASE WHEN p.npi = $1 THEN 1 ELSE 0 END
+		        + ts_rank_cd(p.search_vector, plainto_tsquery('english', $1)))::real AS rank
+		FROM providers p
+		JOIN provider_validation_outcomes o ON o.provider_id = p.id
+	`+searchConditions+`
+		ORDER BY rank DESC, p.provider_name, p.id
+		LIMIT $10 OFFSET $11
+	`, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var result models.ProviderSearchResult
+		err := rows.Scan(
+			&result.ID, &result.UUID, &result.NPI,
+			&result.ProviderName, &result.Specialty, &result.ProviderGroup,
+			&result.States, &result.ValidationStatus, &result.IsActive, &result.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+		page.Results = append(page.Results, result)
+	}
+
+	return page, rows.Err()
+}
@@ -0,0 +1,68 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/npi"
+)
+
+// seedUser registers an agent and returns its ID
+func seedUser(t testing.TB, email string) int {
+	t.Helper()
+	var id int
+	err := database.QueryRow(context.Background(), `
+		INSERT INTO users (email, password) VALUES ($1, 'not-a-hash') RETURNING id
+	`, email).Scan(&id)
+	if err != nil {
+		t.Fatalf("seed user %s: %v", email, err)
+	}
+	return id
+}
+
+// seedProvider adds a provider with the given number of addresses and phones,
+// of which the first validated of each are marked correct
+func seedProvider(t testing.TB, n int, addresses, phones, validated int) int {
+	t.Helper()
+	ctx := context.Background()
+
+	var id int
+	err := database.QueryRow(ctx, `
+		INSERT INTO providers (npi, provider_name) VALUES ($1, $2) RETURNING id
+	`, npi.Complete(fmt.Sprintf("1%08d", n)), fmt.Sprintf("Provider %d", n)).Scan(&id)
+	if err != nil {
+		t.Fatalf("seed provider %d: %v", n, err)
+	}
+
+	for i := 0; i < addresses; i++ {
+		var isCorrect *bool
+		if i < validated {
+			isCorrect = new(bool)
+			*isCorrect = true
+		}
+		err := database.Exec(ctx, `
+			INSERT INTO provider_addresses (provider_id, address1, city, state, zip, is_correct)
+			VALUES ($1, $2, 'SPRINGFIELD', 'IL', '62701', $3)
+		`, id, fmt.Sprintf("%d MAIN ST", 100+i), isCorrect)
+		if err != nil {
+			t.Fatalf("seed address for provider %d: %v", n, err)
+		}
+	}
+	for i := 0; i < phones; i++ {
+		var isCorrect *bool
+		if i < validated {
+			isCorrect = new(bool)
+			*isCorrect = true
+		}
+		err := database.Exec(ctx, `
+			INSERT INTO provider_phones (provider_id, phone, is_correct) VALUES ($1, $2, $3)
+		`, id, fmt.Sprintf("+1217555%04d", 2000+n*10+i), isCorrect)
+		if err != nil {
+			t.Fatalf("seed phone for provider %d: %v", n, err)
+		}
+	}
+
+	return id
+}
@@ -7,131 +7,149 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/address"
 	"github.com/user/auth-app/internal/database"
 	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/phone"
 )
 
 var (
-	ErrNoProvidersAvailable = errors.New("no providers available for validation")
-	ErrSessionLocked        = errors.New("session is locked by another user")
-	ErrInvalidCallAttempt   = errors.New("invalid call attempt")
+	ErrNoProvidersAvailable   = errors.New("no providers available for validation")
+	ErrSessionLocked          = errors.New("session is locked by another user")
+	ErrInvalidCallAttempt     = errors.New("invalid call attempt")
+	ErrSessionNotFound        = errors.New("session not found")
+	ErrSessionNotActive       = errors.New("session is no longer active")
+	ErrInvalidReleaseReason   = errors.New("invalid release reason")
+	ErrInvalidCallbackTime    = errors.New("callback time must be in the future")
+	ErrInvalidPriority        = errors.New("priority must be between 1 and 10")
+	ErrProviderNotFound       = errors.New("provider not found")
+	ErrInvalidSkill           = errors.New("invalid skill")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrProviderAlreadyClaimed = errors.New("provider already has an active session")
+	ErrHandoffNoteRequired    = errors.New("a handoff note is required")
+	ErrAgentBusy              = errors.New("agent already has an active session")
+	ErrInvalidCallOutcome     = errors.New("invalid call outcome")
+	ErrPhoneNotFound          = errors.New("phone not found for this provider")
+	ErrAttemptTooSoon         = errors.New("call attempt is not allowed yet")
+	ErrOutcomeNotAllowed      = errors.New("outcome is not allowed for this attempt")
+	ErrInvalidCallPolicy      = errors.New("invalid call attempt policy")
+	ErrCallPolicyNotFound     = errors.New("call attempt policy not found")
+	ErrInvalidClosure         = errors.New("closure needs a YYYY-MM-DD date, a name and an optional two-letter state")
+	ErrClosureNotFound        = errors.New("closure not found")
+	ErrCallbackNotBusinessDay = errors.New("callback falls on a day the provider's office is closed")
+	ErrOutsideCallingWindow   = errors.New("provider's office is outside calling hours")
+	ErrNotUnreachable         = errors.New("provider can only be marked unreachable after unanswered call attempts")
+	ErrTelephonyDisabled      = errors.New("telephony is not configured")
+	ErrCallInProgress         = errors.New("session already has a call in progress")
+	ErrCallNotFound           = errors.New("call not found")
+	ErrDialFailed             = errors.New("carrier could not place the call")
+	ErrInvalidPhone           = errors.New("phone must be a valid 10-digit US number")
+	ErrInvalidFlag            = errors.New("invalid flag")
+	ErrFlagNotFound           = errors.New("flag not found or already resolved")
+	ErrAddressNotFound        = errors.New("address not found for this provider")
+	ErrPropagationNotFound    = errors.New("propagation not found")
+	ErrPropagationUndone      = errors.New("propagation has already been undone")
+	ErrZipMismatch            = errors.New("zip is not in the address state")
+	ErrInvalidSearch          = errors.New("invalid search filter")
 )
 
 // GetNextProvider gets the next provider for validation using PostgreSQL-optimized queries
 func GetNextProvider(userID int) (*models.ProviderValidationData, error) {
-	ctx := context.Background()
 	log.Printf("GetNextProvider called for userID: %d", userID)
 
+	// A lost claim race rolls back the whole transaction, so retry from the top
+	for attempt := 1; ; attempt++ {
+		result, err := getNextProvider(userID)
+		if err == ErrProviderAlreadyClaimed && attempt < maxClaimAttempts {
+			log.Printf("GetNextProvider: claim conflict for user %d, retrying (attempt %d)", userID, attempt)
+			continue
+		}
+		return result, err
+	}
+}
+
+func getNextProvider(userID int) (*models.ProviderValidationData, error) {
+	ctx := context.Background()
+
 	var result *models.ProviderValidationData
 	err := database.WithTx(ctx, func(tx pgx.Tx) error {
 		// Check if user has an active session first
 		var session models.ValidationSession
-		err := tx.QueryRow(ctx, `
-			SELECT id, uuid, provider_id, user_id, status, priority,
-			       call_attempts, call_attempt_1, call_attempt_2,
-			       started_at, completed_at, locked_at, locked_by,
-			       validation_results, notes, quality_score,
-			       created_at, updated_at, created_by, updated_by
+		err := scanSession(tx.QueryRow(ctx, `
+			SELECT `+sessionColumns+`
 			FROM validation_sessions
 			WHERE user_id = $1 AND status = 'in_progress'
 			ORDER BY created_at DESC
 			LIMIT 1
-		`, userID).Scan(
-			&session.ID, &session.UUID, &session.ProviderID, &session.UserID,
-			&session.Status, &session.Priority, &session.CallAttempts,
-			&session.CallAttempt1, &session.CallAttempt2,
-			&session.StartedAt, &session.CompletedAt, &session.LockedAt, &session.LockedBy,
-			&session.ValidationResults, &session.Notes, &session.QualityScore,
-			&session.CreatedAt, &session.UpdatedAt, &session.CreatedBy, &session.UpdatedBy,
-		)
+		`, userID), &session)
 
 		var provider models.Provider
 		if err == pgx.ErrNoRows {
-			// No active session, find a new provider using PostgreSQL SKIP LOCKED
-			err = tx.QueryRow(ctx, `
-				WITH available_providers AS (
-					SELECT DISTINCT p.id, p.uuid, p.npi, p.gnpi, p.provider_name, 
-					       p.specialty, p.provider_group, p.license_numbers,
-					       p.credentials, p.metadata, p.is_active,
-					       p.created_at, p.updated_at, p.created_by, p.updated_by
-					FROM providers p
-					JOIN provider_addresses pa ON p.id = pa.provider_id
-					JOIN provider_phones pp ON p.id = pp.provider_id
-					LEFT JOIN validation_sessions vs ON p.id = vs.provider_id 
-						AND vs.status = 'in_progress'
-					WHERE p.is_active = true
-					  AND vs.id IS NULL
-					  AND (pa.is_correct IS NULL OR pp.is_correct IS NULL)
-				)
-				SELECT id, uuid, npi, gnpi, provider_name, specialty, provider_group,
-				       license_numbers, credentials, metadata, is_active,
-				       created_at, updated_at, created_by, updated_by
-				FROM available_providers
-				ORDER BY RANDOM()
-				LIMIT 1
-				FOR UPDATE SKIP LOCKED
-			`).Scan(
-				&provider.ID, &provider.UUID, &provider.NPI, &provider.GNPI,
-				&provider.ProviderName, &provider.Specialty, &provider.ProviderGroup,
-				&provider.LicenseNumbers, &provider.Credentials, &provider.Metadata,
-				&provider.IsActive, &provider.CreatedAt, &provider.UpdatedAt,
-				&provider.CreatedBy, &provider.UpdatedBy,
-			)
-
+			// Scheduled callbacks that are due take precedence over fresh work
+			resumed, err := resumeDueCallback(ctx, tx, userID, &session)
 			if err != nil {
-				if err == pgx.ErrNoRows {
-					return ErrNoProvidersAvailable
-				}
 				return err
 			}
+			if resumed {
+				err = loadProvider(ctx, tx, session.ProviderID, &provider)
+				if err != nil {
+					return err
+				}
+			} else {
+				// No active session, find a new provider using PostgreSQL SKIP LOCKED.
+				// Agents with skills get matching providers first.
+				routing, err := claimProviderForUser(ctx, tx, userID, &provider)
+				if err != nil {
+					if err == pgx.ErrNoRows {
+						return ErrNoProvidersAvailable
+					}
+					return err
+				}
 
-			// Create a new validation session with PostgreSQL-specific features
-			err = tx.QueryRow(ctx, `
-				INSERT INTO validation_sessions 
-				(uuid, provider_id, user_id, status, priority, call_attempts, 
-				 started_at, locked_at, locked_by, validation_results, created_by)
-				VALUES ($1, $2, $3, 'in_progress', 5, '[]'::jsonb, 
-				        CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, $3, '{}'::jsonb, $3)
-				RETURNING id, uuid, started_at, locked_at, created_at
-			`, uuid.New(), provider.ID, userID).Scan(
-				&session.ID, &session.UUID, &session.StartedAt, 
-				&session.LockedAt, &session.CreatedAt,
-			)
-			if err != nil {
-				return err
-			}
+				// Create a new validation session with PostgreSQL-specific features
+				err = tx.QueryRow(ctx, `
+					INSERT INTO validation_sessions 
+					(uuid, provider_id, user_id, status, priority, call_attempts, 
+					 started_at, locked_at, locked_by, validation_results, created_by)
+					VALUES ($1, $2, $3, 'in_progress', $4, '[]'::jsonb, 
+					        CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, $3, jsonb_build_object('routing', $5::text), $3)
+					RETURNING id, uuid, status, started_at, locked_at, created_at
+				`, uuid.New(), provider.ID, userID, provider.EffectivePriority, routing).Scan(
+					&session.ID, &session.UUID, &session.Status, &session.StartedAt, 
+					&session.LockedAt, &session.CreatedAt,
+				)
+				if err != nil {
+					if isClaimConflict(err) {
+						return ErrProviderAlreadyClaimed
+					}
+					return err
+				}
+
+				// Triggers may rewrite the new row; only hand out a session that is open
+				if session.Status != "in_progress" {
+					return ErrSessionNotActive
+				}
 
-			// Fill in other session fields
-			session.ProviderID = provider.ID
-			session.UserID = userID
-			session.Status = "in_progress"
-			session.Priority = 5
-			session.LockedBy = models.NullInt64{NullInt64: sql.NullInt64{Int64: int64(userID), Valid: true}}
-			session.CallAttempts = []models.CallAttemptRecord{}
-			session.ValidationResults = make(map[string]interface{})
-			session.CreatedBy = models.NullInt64{NullInt64: sql.NullInt64{Int64: int64(userID), Valid: true}}
-			session.UpdatedAt = session.CreatedAt
+				// Fill in other session fields
+				session.ProviderID = provider.ID
+				session.UserID = userID
+				session.Priority = provider.EffectivePriority
+				session.LockedBy = models.NullInt64{NullInt64: sql.NullInt64{Int64: int64(userID), Valid: true}}
+				session.CallAttempts = []models.CallAttemptRecord{}
+				session.ValidationResults = map[string]interface{}{"routing": routing}
+				session.CreatedBy = models.NullInt64{NullInt64: sql.NullInt64{Int64: int64(userID), Valid: true}}
+				session.UpdatedAt = session.CreatedAt
+			}
 		} else if err != nil {
 			return err
 		} else {
 			// Load the provider from the existing session
-			err = tx.QueryRow(ctx, `
-				SELECT id, uuid, npi, gnpi, provider_name, specialty, provider_group,
-				       license_numbers, credentials, metadata, is_active,
-				       created_at, updated_at, created_by, updated_by
-				FROM providers
-				WHERE id = $1
-			`, session.ProviderID).Scan(
-				&provider.ID, &provider.UUID, &provider.NPI, &provider.GNPI,
-				&provider.ProviderName, &provider.Specialty, &provider.ProviderGroup,
-				&provider.LicenseNumbers, &provider.Credentials, &provider.Metadata,
-				&provider.IsActive, &provider.CreatedAt, &provider.UpdatedAt,
-				&provider.CreatedBy, &provider.UpdatedBy,
-			)
+			err = loadProvider(ctx, tx, session.ProviderID, &provider)
 			if err != nil {
 				return err
 			}
@@ -148,15 +166,25 @@ func GetNextProvider(userID int) (*models.ProviderValidationData, error) {
 			return err
 		}
 
+		// Phones whose area code is outside every address state are likely wrong
+		warnings := checkAreaCodes(addresses, phones)
+
 		// Create address-phone records for backward compatibility
 		addressPhoneRecords := createAddressPhoneRecords(addresses, phones)
 
+		localTime, err := providerLocalTime(ctx, tx, provider.ID)
+		if err != nil {
+			return err
+		}
+
 		result = &models.ProviderValidationData{
 			Provider:            provider,
 			AddressPhoneRecords: addressPhoneRecords,
 			Addresses:           addresses,
 			Phones:              phones,
 			ValidationSession:   &session,
+			ProviderLocalTime:   localTime,
+			Warnings:            warnings,
 		}
 
 		return nil
@@ -169,6 +197,84 @@ func GetNextProvider(userID int) (*models.ProviderValidationData, error) {
 	return result, nil
 }
 
+// claimNextProvider takes the highest-priority provider off the work queue for
+// userID. When skillsOnly is set, only providers matching the agent's skills qualify.
+func claimNextProvider(ctx context.Context, tx pgx.Tx, userID int, skillsOnly bool, provider *models.Provider) error {
+	var providerID, priority int
+	err := tx.QueryRow(ctx, `
+		SELECT wq.provider_id, wq.priority
+		FROM work_queue wq
+		WHERE NOT EXISTS (
+			SELECT 1 FROM validation_sessions rs
+			WHERE rs.provider_id = wq.provider_id
+			  AND rs.user_id = $1
+			  AND rs.status = 'cancelled'
+			  AND (rs.validation_results->>'exclude_releaser')::boolean
+		  )
+		  AND (NOT $2::boolean OR `+skillMatchCondition+`)
+		  AND `+callingWindowCondition+`
+		ORDER BY wq.priority DESC, wq.due_date ASC NULLS LAST, wq.queued_at, wq.provider_id
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`, userID, skillsOnly, config.CallWindowEnabled, config.CallWindowStart, config.CallWindowEnd,
+		holidayDates(time.Now())).Scan(&providerID, &priority)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM work_queue WHERE provider_id = $1`, providerID)
+	if err != nil {
+		return err
+	}
+
+	err = loadProvider(ctx, tx, providerID, provider)
+	if err != nil {
+		return err
+	}
+	provider.EffectivePriority = priority
+
+	return nil
+}
+
+// sessionColumns lists validation_sessions columns in the order scanSession expects
+const sessionColumns = `id, uuid, provider_id, user_id, status, priority,
+			       call_attempts, call_attempt_1, call_attempt_2,
+			       started_at, completed_at, locked_at, locked_by,
+			       validation_results, notes, quality_score, callback_at,
+			       created_at, updated_at, created_by, updated_by`
+
+// scanSession scans a row selected with sessionColumns
+func scanSession(row pgx.Row, session *models.ValidationSession) error {
+	return row.Scan(
+		&session.ID, &session.UUID, &session.ProviderID, &session.UserID,
+		&session.Status, &session.Priority, &session.CallAttempts,
+		&session.CallAttempt1, &session.CallAttempt2,
+		&session.StartedAt, &session.CompletedAt, &session.LockedAt, &session.LockedBy,
+		&session.ValidationResults, &session.Notes, &session.QualityScore, &session.CallbackAt,
+		&session.CreatedAt, &session.UpdatedAt, &session.CreatedBy, &session.UpdatedBy,
+	)
+}
+
+// loadProvider retrieves a single provider by ID
+func loadProvider(ctx context.Context, tx pgx.Tx, providerID int, provider *models.Provider) error {
+	return tx.QueryRow(ctx, `
+		SELECT id, uuid, npi, gnpi, provider_name, specialty, provider_group,
+		       license_numbers, credentials, metadata, is_active,
+		       created_at, updated_at, created_by, updated_by,
+		       priority, due_date,
+		       provider_effective_priority(priority, created_at, $2::integer)
+		FROM providers
+		WHERE id = $1
+	`, providerID, agingSeconds()).Scan(
+		&provider.ID, &provider.UUID, &provider.NPI, &provider.GNPI,
+		&provider.ProviderName, &provider.Specialty, &provider.ProviderGroup,
+		&provider.LicenseNumbers, &provider.Credentials, &provider.Metadata,
+		&provider.IsActive, &provider.CreatedAt, &provider.UpdatedAt,
+		&provider.CreatedBy, &provider.UpdatedBy,
+		&provider.Priority, &provider.DueDate, &provider.EffectivePriority,
+	)
+}
+
 // getProviderAddresses retrieves all addresses for a provider
 func getProviderAddresses(ctx context.Context, tx pgx.Tx, providerID int) ([]models.ProviderAddress, error) {
 	rows, err := tx.Query(ctx, `
@@ -211,15 +317,25 @@ func getProviderAddresses(ctx context.Context, tx pgx.Tx, providerID int) ([]mod
 
 // getProviderPhones retrieves all phones for a provider
 func getProviderPhones(ctx context.Context, tx pgx.Tx, providerID int) ([]models.ProviderPhone, error) {
+	// Active global flags on the number (or its correction) mark the phone
 	rows, err := tx.Query(ctx, `
-		SELECT id, uuid, provider_id, phone, phone_type, extension,
-		       is_correct, corrected_phone, validation_notes, validation_metadata,
-		       call_attempts, is_flagged, flag_reason, confidence_score,
-		       validated_by, validated_at, created_at, updated_at,
-		       created_by, updated_by, link_id
-		FROM provider_phones
-		WHERE provider_id = $1
-		ORDER BY created_at
+		SELECT pp.id, pp.uuid, pp.provider_id, pp.phone, pp.phone_type, pp.extension,
+		       pp.is_correct, pp.corrected_phone, pp.validation_notes, pp.validation_metadata,
+		       pp.call_attempts, COALESCE(pp.is_flagged, false) OR fp.id IS NOT NULL, COALESCE(fp.flag_reason, pp.flag_reason),
+		       fp.flag_type, fp.severity, pp.confidence_score,
+		       pp.validated_by, pp.validated_at, pp.created_at, pp.updated_at,
+		       pp.created_by, pp.updated_by, pp.link_id
+		FROM provider_phones pp
+		LEFT JOIN LATERAL (
+			SELECT f.id, f.flag_type, f.flag_reason, f.severity
+			FROM flagged_phones f
+			WHERE f.is_active
+			  AND f.phone IN (normalize_phone(pp.phone), normalize_phone(pp.corrected_phone))
+			ORDER BY f.severity DESC
+			LIMIT 1
+		) fp ON true
+		WHERE pp.provider_id = $1
+		ORDER BY pp.created_at
 	`, providerID)
 	if err != nil {
 		return nil, err
@@ -235,7 +351,7 @@ func getProviderPhones(ctx context.Context, tx pgx.Tx, providerID int) ([]models
 			&phone.ID, &phone.UUID, &phone.ProviderID, &phone.Phone,
 			&phone.PhoneType, &phone.Extension, &phone.IsCorrect, &phone.CorrectedPhone,
 			&phone.ValidationNotes, &phone.ValidationMetadata, &callAttemptsJSON,
-			&phone.IsFlagged, &phone.FlagReason, &phone.ConfidenceScore,
+			&phone.IsFlagged, &phone.FlagReason, &phone.FlagType, &phone.FlagSeverity, &phone.ConfidenceScore,
 			&phone.ValidatedBy, &phone.ValidatedAt, &phone.CreatedAt, &phone.UpdatedAt,
 			&phone.CreatedBy, &phone.UpdatedBy, &phone.LinkID,
 		)
@@ -243,6 +359,12 @@ func getProviderPhones(ctx context.Context, tx pgx.Tx, providerID int) ([]models
 			return nil, err
 		}
 
+		phone.PhoneDisplay = displayPhone(phone.Phone, phone.Extension.String)
+		if phone.CorrectedPhone.Valid {
+			extension, _ := phone.ValidationMetadata["corrected_extension"].(string)
+			phone.CorrectedDisplay = displayPhone(phone.CorrectedPhone.String, extension)
+		}
+
 		// Parse call attempts JSON
 		if len(callAttemptsJSON) > 0 {
 			err = json.Unmarshal(callAttemptsJSON, &phone.CallAttempts)
@@ -294,11 +416,14 @@ func createAddressPhoneRecords(addresses []models.ProviderAddress, phones []mode
 	return records
 }
 
-// UpdateValidation updates validation data using PostgreSQL transactions and JSONB
-func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate) error {
+// UpdateValidation updates validation data using PostgreSQL transactions and JSONB.
+// It returns warnings about corrected or new addresses that disagree with the
+// ZIP reference.
+func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate) ([]string, error) {
 	ctx := context.Background()
 
-	return database.WithTx(ctx, func(tx pgx.Tx) error {
+	var warnings []string
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
 		// Verify session ownership with row-level locking
 		var sessionUserID int
 		var providerID int
@@ -313,11 +438,13 @@ func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate)
 			}
 			return err
 		}
-		if sessionUserID != userID {
-			return ErrSessionLocked
+		override, err := authorizeSessionUser(sessionUserID, userID)
+		if err != nil {
+			return err
 		}
 
 		// Update addresses with enhanced audit trail
+		addressesPropagated := 0
 		for _, addrVal := range update.AddressValidations {
 			validationMetadata := map[string]interface{}{
 				"validated_at": time.Now(),
@@ -338,6 +465,25 @@ func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate)
 				`, true, validationMetadata, userID, addrVal.AddressID)
 			} else {
 				validationMetadata["corrections_made"] = true
+				// Corrections are stored in USPS standard form
+				corrected, stdErr := address.Standardize(address.Address{
+					Address1: addrVal.CorrectedAddress1,
+					Address2: addrVal.CorrectedAddress2,
+					City:     addrVal.CorrectedCity,
+					State:    addrVal.CorrectedState,
+					Zip:      addrVal.CorrectedZip,
+				})
+				if stdErr != nil {
+					return stdErr
+				}
+				issues, err := checkCorrectedAddress(ctx, tx, addrVal.AddressID, corrected)
+				if err != nil {
+					return err
+				}
+				if len(issues) > 0 {
+					validationMetadata["reference_warnings"] = issues
+					warnings = append(warnings, issueMessages(issues)...)
+				}
 				_, err = tx.Exec(ctx, `
 					UPDATE provider_addresses 
 					SET is_correct = $1,
@@ -348,19 +494,29 @@ func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate)
 					    updated_by = $8, updated_at = CURRENT_TIMESTAMP
 					WHERE id = $9
 				`, false, 
-					nullStringValue(addrVal.CorrectedAddress1),
-					nullStringValue(addrVal.CorrectedAddress2),
-					nullStringValue(addrVal.CorrectedCity),
-					nullStringValue(addrVal.CorrectedState),
-					nullStringValue(addrVal.CorrectedZip),
+					nullStringValue(corrected.Address1),
+					nullStringValue(corrected.Address2),
+					nullStringValue(corrected.City),
+					nullStringValue(corrected.State),
+					nullStringValue(corrected.Zip),
 					validationMetadata, userID, addrVal.AddressID)
 			}
 			if err != nil {
 				return err
 			}
+
+			// Apply the same result to this address at other providers
+			if addrVal.Propagate {
+				count, err := propagateAddressValidation(ctx, tx, sessionID, userID, providerID, addrVal.AddressID, addrVal.PropagateWithinGroup)
+				if err != nil {
+					return err
+				}
+				addressesPropagated += count
+			}
 		}
 
 		// Update phones with enhanced tracking
+		phonesPropagated := 0
 		for _, phoneVal := range update.PhoneValidations {
 			validationMetadata := map[string]interface{}{
 				"validated_at": time.Now(),
@@ -379,6 +535,19 @@ func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate)
 				`, true, validationMetadata, userID, phoneVal.PhoneID)
 			} else {
 				validationMetadata["corrections_made"] = true
+				// Corrections are stored in E.164 so they match other records
+				var correctedPhone *string
+				if strings.TrimSpace(phoneVal.CorrectedPhone) != "" {
+					number, err := phone.Parse(phoneVal.CorrectedPhone)
+					if err != nil {
+						return ErrInvalidPhone
+					}
+					e164 := number.E164()
+					correctedPhone = &e164
+					if number.Extension != "" {
+						validationMetadata["corrected_extension"] = number.Extension
+					}
+				}
 				_, err = tx.Exec(ctx, `
 					UPDATE provider_phones 
 					SET is_correct = $1, corrected_phone = $2,
@@ -386,133 +555,260 @@ func UpdateValidation(sessionID int, userID int, update models.ValidationUpdate)
 					    validated_by = $4, validated_at = CURRENT_TIMESTAMP,
 					    updated_by = $4, updated_at = CURRENT_TIMESTAMP
 					WHERE id = $5
-				`, false, nullStringValue(phoneVal.CorrectedPhone),
+				`, false, correctedPhone,
 					validationMetadata, userID, phoneVal.PhoneID)
 			}
 			if err != nil {
 				return err
 			}
+
+			// Apply the same result to other providers listing this number
+			if phoneVal.Propagate {
+				count, err := propagatePhoneValidation(ctx, tx, sessionID, userID, providerID, phoneVal.PhoneID)
+				if err != nil {
+					return err
+				}
+				phonesPropagated += count
+			}
 		}
 
 		// Add new addresses with UUID and audit trail
 		for _, newAddr := range update.NewAddresses {
+			std, err := address.Standardize(address.Address{
+				Address1: newAddr.Address1,
+				Address2: newAddr.Address2,
+				City:     newAddr.City,
+				State:    newAddr.State,
+				Zip:      newAddr.Zip,
+			})
+			if err != nil {
+				return err
+			}
+			issues, err := checkZip(std.State, std.City, std.Zip)
+			if err != nil {
+				return err
+			}
+			metadata := map[string]interface{}{}
+			if len(issues) > 0 {
+				metadata["reference_warnings"] = issues
+				warnings = append(warnings, issueMessages(issues)...)
+			}
 			_, err = tx.Exec(ctx, `
 				INSERT INTO provider_addresses 
-				(uuid, provider_id, address_category, address1, address2, city, state, zip,
-				 is_correct, validated_by, validated_at, created_by, updated_by)
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, true, $9, CURRENT_TIMESTAMP, $9, $9)
-			`, uuid.New(), providerID, newAddr.AddressCategory, newAddr.Address1,
-				nullStringValue(newAddr.Address2), newAddr.City, newAddr.State, 
-				newAddr.Zip, userID)
+				(uuid, provider_id, address_category, address1, address2, city, state, zip, address_key,
+				 is_correct, validation_metadata, validated_by, validated_at, created_by, updated_by)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $11, true, $10, $9, CURRENT_TIMESTAMP, $9, $9)
+			`, uuid.New(), providerID, newAddr.AddressCategory, std.Address1,
+				nullStringValue(std.Address2), std.City, std.State, 
+				std.Zip, userID, metadata, address.Key(std))
 			if err != nil {
 				return err
 			}
 		}
 
 		// Update session with validation progress
+		sessionResults := map[string]interface{}{
+			"last_update": time.Now(),
+			"addresses_updated": len(update.AddressValidations),
+			"phones_updated": len(update.PhoneValidations),
+			"new_addresses_added": len(update.NewAddresses),
+		}
+		if addressesPropagated > 0 {
+			sessionResults["addresses_propagated"] = addressesPropagated
+		}
+		if phonesPropagated > 0 {
+			sessionResults["phones_propagated"] = phonesPropagated
+		}
+		if override {
+			sessionResults["supervisor_override_by"] = userID
+		}
 		_, err = tx.Exec(ctx, `
 			UPDATE validation_sessions 
 			SET validation_results = validation_results || $1::jsonb,
 			    updated_by = $2, updated_at = CURRENT_TIMESTAMP
 			WHERE id = $3
-		`, map[string]interface{}{
-			"last_update": time.Now(),
-			"addresses_updated": len(update.AddressValidations),
-			"phones_updated": len(update.PhoneValidations),
-			"new_addresses_added": len(update.NewAddresses),
-		}, userID, sessionID)
+		`, sessionResults, userID, sessionID)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return warnings, nil
+}
 
+// RecordCallAttempt records the outcome of dialing one of the provider's
+// phones, on the session and on the phone's own call history. The provider's
+// call attempt policy decides how many attempts are allowed, how far apart
+// they must be, and which outcomes each may record.
+func RecordCallAttempt(sessionID int, userID int, req models.CallAttemptRequest) (*models.CallAttemptStatus, error) {
+	ctx := context.Background()
+
+	var result *models.CallAttemptStatus
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		result, err = recordCallAttempt(ctx, tx, sessionID, userID, req)
 		return err
 	})
+
+	return result, err
 }
 
-// RecordCallAttempt records a call attempt with enhanced tracking
-func RecordCallAttempt(sessionID int, userID int, attemptNumber int) error {
-	if attemptNumber != 1 && attemptNumber != 2 {
-		return ErrInvalidCallAttempt
+// recordCallAttempt does the work of RecordCallAttempt inside the caller's
+// transaction. Policy checks run before anything is written.
+func recordCallAttempt(ctx context.Context, tx pgx.Tx, sessionID int, userID int, req models.CallAttemptRequest) (*models.CallAttemptStatus, error) {
+	attemptNumber := req.AttemptNumber
+	if attemptNumber < 1 || req.Duration < 0 {
+		return nil, ErrInvalidCallAttempt
+	}
+	// Every attempt names the phone dialed and how the call ended
+	outcome := req.Outcome
+	if req.PhoneID == 0 || !callOutcomes[outcome] {
+		return nil, ErrInvalidCallOutcome
 	}
 
-	ctx := context.Background()
+	// Get current session data with locking
+	var sessionUserID, providerID int
+	var callAttemptsJSON []byte
+	
+	err := tx.QueryRow(ctx, `
+		SELECT user_id, provider_id, call_attempts
+		FROM validation_sessions 
+		WHERE id = $1 AND status = 'in_progress'
+		FOR UPDATE
+	`, sessionID).Scan(&sessionUserID, &providerID, &callAttemptsJSON)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+		return nil, err
+	}
 
-	return database.WithTx(ctx, func(tx pgx.Tx) error {
-		// Get current session data with locking
-		var sessionUserID int
-		var callAttemptsJSON []byte
-		var attempt1 models.NullTime
-		
-		err := tx.QueryRow(ctx, `
-			SELECT user_id, call_attempts, call_attempt_1 
-			FROM validation_sessions 
-			WHERE id = $1 AND status = 'in_progress'
-			FOR UPDATE
-		`, sessionID).Scan(&sessionUserID, &callAttemptsJSON, &attempt1)
+	// Calls outside the provider's office hours are refused or flagged
+	localTime, err := providerLocalTime(ctx, tx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if !localTime.WithinCallingWindow && config.CallWindowRefuse {
+		return nil, ErrOutsideCallingWindow
+	}
+
+	// Parse existing call attempts
+	var callAttempts []models.CallAttemptRecord
+	if len(callAttemptsJSON) > 0 {
+		err = json.Unmarshal(callAttemptsJSON, &callAttempts)
 		if err != nil {
-			return err
-		}
-		if sessionUserID != userID {
-			return ErrSessionLocked
+			callAttempts = []models.CallAttemptRecord{}
 		}
+	}
 
-		// Parse existing call attempts
-		var callAttempts []models.CallAttemptRecord
-		if len(callAttemptsJSON) > 0 {
-			err = json.Unmarshal(callAttemptsJSON, &callAttempts)
-			if err != nil {
-				callAttempts = []models.CallAttemptRecord{}
-			}
+	// Enforce the call attempt policy
+	policy, err := loadCallAttemptPolicy(ctx, tx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	cal, err := loadProviderCalendar(ctx, tx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	// Attempts are made in order; the latest may be logged again for another phone
+	status := callAttemptStatus(sessionID, policy, cal, callAttempts)
+	if attemptNumber > policy.MaxAttempts || attemptNumber > status.AttemptsMade+1 {
+		return nil, ErrInvalidCallAttempt
+	}
+	if allowedAt := attemptAllowedAt(policy, cal, callAttempts, attemptNumber); time.Now().Before(allowedAt) {
+		return status, ErrAttemptTooSoon
+	}
+	if allowed := policyStep(policy, attemptNumber).AllowedOutcomes; len(allowed) > 0 {
+		permitted := false
+		for _, o := range allowed {
+			permitted = permitted || o == outcome
 		}
-
-		// Business day validation for attempt 2
-		if attemptNumber == 2 && attempt1.Valid {
-			if !isNextBusinessDay(attempt1.Time, time.Now()) {
-				return errors.New("call attempt 2 must be at least 1 business day after attempt 1")
-			}
+		if !permitted {
+			return nil, ErrOutcomeNotAllowed
 		}
+	}
 
-		// Create new call attempt record
-		newAttempt := models.CallAttemptRecord{
-			AttemptNumber: attemptNumber,
-			AttemptedAt:   time.Now(),
-			Status:        "attempted", // Default status
-			Notes:         fmt.Sprintf("Call attempt %d recorded", attemptNumber),
-		}
-		
-		// Add to attempts array (replace if same attempt number exists)
-		found := false
-		for i, attempt := range callAttempts {
-			if attempt.AttemptNumber == attemptNumber {
-				callAttempts[i] = newAttempt
-				found = true
-				break
-			}
-		}
-		if !found {
-			callAttempts = append(callAttempts, newAttempt)
+	// Create new call attempt record
+	newAttempt := models.CallAttemptRecord{
+		AttemptNumber: attemptNumber,
+		AttemptedAt:   time.Now(),
+		Status:        outcome,
+		Notes:         req.Notes,
+		Duration:      req.Duration,
+		PhoneID:       req.PhoneID,
+		SessionID:     sessionID,
+		UserID:        userID,
+		CallID:        req.CallID,
+	}
+	if newAttempt.Notes == "" {
+		newAttempt.Notes = fmt.Sprintf("Call attempt %d recorded", attemptNumber)
+	}
+
+	err = tx.QueryRow(ctx, `
+		SELECT phone FROM provider_phones WHERE id = $1 AND provider_id = $2
+	`, req.PhoneID, providerID).Scan(&newAttempt.Phone)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrPhoneNotFound
 		}
+		return nil, err
+	}
 
-		// Update both the JSONB array and legacy timestamp fields
-		callAttemptsUpdated, _ := json.Marshal(callAttempts)
-		
-		if attemptNumber == 1 {
-			_, err = tx.Exec(ctx, `
-				UPDATE validation_sessions 
-				SET call_attempts = $1::jsonb,
-				    call_attempt_1 = CURRENT_TIMESTAMP,
-				    updated_by = $2, updated_at = CURRENT_TIMESTAMP
-				WHERE id = $3
-			`, callAttemptsUpdated, userID, sessionID)
-		} else {
-			_, err = tx.Exec(ctx, `
-				UPDATE validation_sessions 
-				SET call_attempts = $1::jsonb,
-				    call_attempt_2 = CURRENT_TIMESTAMP,
-				    updated_by = $2, updated_at = CURRENT_TIMESTAMP
-				WHERE id = $3
-			`, callAttemptsUpdated, userID, sessionID)
+	// The phone keeps every attempt across sessions for per-number reporting
+	_, err = tx.Exec(ctx, `
+		UPDATE provider_phones
+		SET call_attempts = COALESCE(call_attempts, '[]'::jsonb) || jsonb_build_array($1::jsonb),
+		    updated_by = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3
+	`, newAttempt, userID, req.PhoneID)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Add to attempts array (replace if the same phone was already logged for this attempt)
+	found := false
+	for i, attempt := range callAttempts {
+		if attempt.AttemptNumber == attemptNumber && attempt.PhoneID == req.PhoneID {
+			callAttempts[i] = newAttempt
+			found = true
+			break
 		}
+	}
+	if !found {
+		callAttempts = append(callAttempts, newAttempt)
+	}
 
-		return err
-	})
+	// Update the JSONB array, and the legacy timestamp fields for attempts 1 and 2
+	callAttemptsUpdated, _ := json.Marshal(callAttempts)
+	
+	_, err = tx.Exec(ctx, `
+		UPDATE validation_sessions 
+		SET call_attempts = $1::jsonb,
+		    call_attempt_1 = CASE WHEN $2::integer = 1 THEN CURRENT_TIMESTAMP ELSE call_attempt_1 END,
+		    call_attempt_2 = CASE WHEN $2::integer = 2 THEN CURRENT_TIMESTAMP ELSE call_attempt_2 END,
+		    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $4
+	`, callAttemptsUpdated, attemptNumber, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := callAttemptStatus(sessionID, policy, cal, callAttempts)
+	result.ProviderLocalTime = localTime
+	if !localTime.WithinCallingWindow {
+		result.Warnings = append(result.Warnings, "call recorded outside the provider's office hours")
+	}
+
+	// Every allowed attempt went unanswered: close the provider out
+	if config.AutoDisposition && result.AttemptsMade >= policy.MaxAttempts && allAttemptsUnreachable(callAttempts) {
+		if err := disposeUnreachable(ctx, tx, sessionID, providerID, userID, callAttempts, true, ""); err != nil {
+			return nil, err
+		}
+		result.Disposition = DispositionUnreachable
+	}
+	return result, nil
 }
 
 // CompleteValidation completes a validation session with quality scoring
@@ -533,8 +829,9 @@ func CompleteValidation(sessionID int, userID int) error {
 		if err != nil {
 			return err
 		}
-		if sessionUserID != userID {
-			return ErrSessionLocked
+		override, err := authorizeSessionUser(sessionUserID, userID)
+		if err != nil {
+			return err
 		}
 
 		// Check validation completeness using PostgreSQL aggregates
@@ -574,6 +871,9 @@ func CompleteValidation(sessionID int, userID int) error {
 			"completed_by": userID,
 			"completion_timestamp": time.Now(),
 		}
+		if override {
+			completionResults["supervisor_override_by"] = userID
+		}
 
 		_, err = tx.Exec(ctx, `
 			UPDATE validation_sessions 
@@ -626,6 +926,18 @@ func calculateQualityScore(itemsValidated int, completionTime time.Duration) flo
 	return score
 }
 
+// displayPhone formats a stored number and its extension for display
+func displayPhone(stored string, extension string) string {
+	number, err := phone.Parse(stored)
+	if err != nil {
+		return stored
+	}
+	if extension != "" {
+		number.Extension = extension
+	}
+	return number.Display()
+}
+
 // Helper function for null string values
 func nullStringValue(s string) *string {
 	if s == "" {
@@ -634,45 +946,6 @@ func nullStringValue(s string) *string {
 	return &s
 }
 
-// SearchProviders performs full-text search using PostgreSQL
-func SearchProviders(searchTerm string, limit, offset int) ([]models.ProviderSearchResult, error) {
-	ctx := context.Background()
-	
-	rows, err := database.Query(ctx, `
-		SELECT id, uuid, npi, provider_name, 
-		       COALESCE(specialty, '') as specialty, 
-		       COALESCE(provider_group, '') as provider_group,
-		       ts_rank_cd(search_vector, plainto_tsquery('english', $1)) as rank
-		FROM providers
-		WHERE search_vector @@ plainto_tsquery('english', $1)
-		   OR npi ILIKE '%' || $1 || '%'
-		   OR provider_name ILIKE '%' || $1 || '%'
-		   AND is_active = true
-		ORDER BY rank DESC, provider_name
-		LIMIT $2 OFFSET $3
-	`, searchTerm, limit, offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var results []models.ProviderSearchResult
-	for rows.Next() {
-		var result models.ProviderSearchResult
-		err := rows.Scan(
-			&result.ID, &result.UUID, &result.NPI,
-			&result.ProviderName, &result.Specialty, &result.ProviderGroup,
-			&result.Rank,
-		)
-		if err != nil {
-			return nil, err
-		}
-		results = append(results, result)
-	}
-
-	return results, rows.Err()
-}
-
 // GetValidationStats gets enhanced validation statistics
 func GetValidationStats(userID int) (*models.ValidationStats, error) {
 	ctx := context.Background()
@@ -704,24 +977,6 @@ func GetValidationStats(userID int) (*models.ValidationStats, error) {
 	return &stats, nil
 }
 
-// Business day calculation (same as before)
-func isNextBusinessDay(t1, t2 time.Time) bool {
-	days := 0
-	current := t1.AddDate(0, 0, 1)
-	
-	for current.Before(t2) || current.Equal(t2) {
-		if current.Weekday() != time.Saturday && current.Weekday() != time.Sunday {
-			days++
-		}
-		if days >= 1 {
-			return true
-		}
-		current = current.AddDate(0, 0, 1)
-	}
-	
-	return false
-}
-
 // GetValidationPreview gets preview of validation session status
 func GetValidationPreview(sessionID int, userID int) (*ValidationPreview, error) {
 	ctx := context.Background()
@@ -740,8 +995,8 @@ func GetValidationPreview(sessionID int, userID int) (*ValidationPreview, error)
 			}
 			return err
 		}
-		if sessionUserID != userID {
-			return ErrSessionLocked
+		if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+			return err
 		}
 
 		preview := &ValidationPreview{
@@ -862,16 +1117,7 @@ func GetStats(userID int) (map[string]interface{}, error) {
 
 	// Total providers needing validation
 	var totalPending int
-	err := database.QueryRow(ctx, `
-		SELECT COUNT(DISTINCT p.id)
-		FROM providers p
-		JOIN provider_addresses pa ON p.id = pa.provider_id
-		JOIN provider_phones pp ON p.id = pp.provider_id
-		LEFT JOIN validation_sessions vs ON p.id = vs.provider_id AND vs.status = 'in_progress'
-		WHERE p.is_active = true
-		  AND vs.id IS NULL
-		  AND (pa.is_correct IS NULL OR pp.is_correct IS NULL)
-	`).Scan(&totalPending)
+	err := database.QueryRow(ctx, `SELECT COUNT(*) FROM work_queue`).Scan(&totalPending)
 	if err != nil {
 		return nil, err
 	}
@@ -899,5 +1145,26 @@ func GetStats(userID int) (map[string]interface{}, error) {
 	}
 	stats["in_progress"] = inProgress
 
+	// Parked sessions awaiting a callback
+	var onHold int
+	err = database.QueryRow(ctx, `
+		SELECT COUNT(*) FROM validation_sessions 
+		WHERE user_id = $1 AND status = 'on_hold'
+	`, userID).Scan(&onHold)
+	if err != nil {
+		return nil, err
+	}
+	stats["on_hold"] = onHold
+
+	// Providers closed out as unreachable
+	var unreachable int
+	err = database.QueryRow(ctx, `
+		SELECT COUNT(*) FROM providers WHERE disposition = $1
+	`, DispositionUnreachable).Scan(&unreachable)
+	if err != nil {
+		return nil, err
+	}
+	stats["total_unreachable"] = unreachable
+
 	return stats, nil
 }
\ No newline at end of file
@@ -0,0 +1,273 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// HeartbeatSession renews the caller's lock on an in-progress session
+func HeartbeatSession(sessionID int, userID int) (*models.SessionLockStatus, error) {
+	ctx := context.Background()
+
+	var result *models.SessionLockStatus
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var status string
+		var lockHolder int
+		err := tx.QueryRow(ctx, `
+			SELECT status, COALESCE(locked_by, user_id)
+			FROM validation_sessions
+			WHERE id = $1
+			FOR UPDATE
+		`, sessionID).Scan(&status, &lockHolder)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if status != "in_progress" {
+			return ErrSessionNotActive
+		}
+		if lockHolder != userID {
+			return ErrSessionLocked
+		}
+
+		var lockedAt time.Time
+		err = tx.QueryRow(ctx, `
+			UPDATE validation_sessions
+			SET locked_at = CURRENT_TIMESTAMP, locked_by = $1,
+			    updated_by = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2
+			RETURNING locked_at
+		`, userID, sessionID).Scan(&lockedAt)
+		if err != nil {
+			return err
+		}
+
+		result = lockStatus(sessionID, lockedAt)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// lockStatus computes lock expiry from the configured session TTL
+func lockStatus(sessionID int, lockedAt time.Time) *models.SessionLockStatus {
+	expiresAt := lockedAt.Add(config.SessionTTL)
+	remaining := int(time.Until(expiresAt).Seconds())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &models.SessionLockStatus{
+		SessionID:        sessionID,
+		LockedAt:         lockedAt,
+		ExpiresAt:        expiresAt,
+		RemainingSeconds: remaining,
+	}
+}
+
+// Release reason codes accepted by ReleaseSession
+var releaseReasons = map[string]bool{
+	"cannot_reach":     true,
+	"language_barrier": true,
+	"needs_research":   true,
+	"technical_issue":  true,
+	"end_of_shift":     true,
+	"other":            true,
+}
+
+// ReleaseSession cancels the caller's session without requiring every item to
+// be validated. Partial validations are kept and the provider returns to the queue.
+func ReleaseSession(sessionID int, userID int, req models.ReleaseSessionRequest) error {
+	if !releaseReasons[req.ReasonCode] {
+		return ErrInvalidReleaseReason
+	}
+
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var status string
+		var sessionUserID int
+		err := tx.QueryRow(ctx, `
+			SELECT status, user_id
+			FROM validation_sessions
+			WHERE id = $1
+			FOR UPDATE
+		`, sessionID).Scan(&status, &sessionUserID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if status != "in_progress" {
+			return ErrSessionNotActive
+		}
+		if sessionUserID != userID {
+			return ErrSessionLocked
+		}
+
+		releaseResults := map[string]interface{}{
+			"released_at":      time.Now(),
+			"released_by":      userID,
+			"release_reason":   req.ReasonCode,
+			"exclude_releaser": req.ExcludeSelf,
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE validation_sessions
+			SET status = 'cancelled',
+			    locked_by = NULL,
+			    notes = COALESCE($1, notes),
+			    validation_results = validation_results || $2::jsonb,
+			    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $4
+		`, nullStringValue(req.Notes), releaseResults, userID, sessionID)
+
+		return err
+	})
+}
+
+// HoldSession parks the caller's session until callbackAt, which must fall on a
+// business day in the provider's time zone and states. The provider stays out
+// of the general queue and returns to the same agent when the callback is due.
+func HoldSession(sessionID int, userID int, req models.HoldSessionRequest) error {
+	if !req.CallbackAt.After(time.Now()) {
+		return ErrInvalidCallbackTime
+	}
+
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var status string
+		var sessionUserID, providerID int
+		err := tx.QueryRow(ctx, `
+			SELECT status, user_id, provider_id
+			FROM validation_sessions
+			WHERE id = $1
+			FOR UPDATE
+		`, sessionID).Scan(&status, &sessionUserID, &providerID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if status != "in_progress" {
+			return ErrSessionNotActive
+		}
+		if sessionUserID != userID {
+			return ErrSessionLocked
+		}
+
+		cal, err := loadProviderCalendar(ctx, tx, providerID)
+		if err != nil {
+			return err
+		}
+		if !cal.IsBusinessDay(req.CallbackAt) {
+			return ErrCallbackNotBusinessDay
+		}
+
+		holdResults := map[string]interface{}{
+			"held_at":     time.Now(),
+			"held_by":     userID,
+			"callback_at": req.CallbackAt,
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE validation_sessions
+			SET status = 'on_hold',
+			    callback_at = $1,
+			    locked_by = NULL,
+			    notes = COALESCE($2, notes),
+			    validation_results = validation_results || $3::jsonb,
+			    updated_by = $4, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $5
+		`, req.CallbackAt, nullStringValue(req.Notes), holdResults, userID, sessionID)
+
+		return err
+	})
+}
+
+// ListCallbacks returns the caller's on-hold sessions ordered by callback time
+func ListCallbacks(userID int) ([]models.ScheduledCallback, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT vs.id, vs.provider_id, p.npi, p.provider_name, vs.callback_at,
+		       vs.notes, vs.callback_at <= CURRENT_TIMESTAMP AS is_due
+		FROM validation_sessions vs
+		JOIN providers p ON p.id = vs.provider_id
+		WHERE vs.user_id = $1 AND vs.status = 'on_hold'
+		ORDER BY vs.callback_at
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	callbacks := []models.ScheduledCallback{}
+	for rows.Next() {
+		var cb models.ScheduledCallback
+		err := rows.Scan(
+			&cb.SessionID, &cb.ProviderID, &cb.NPI, &cb.ProviderName,
+			&cb.CallbackAt, &cb.Notes, &cb.IsDue,
+		)
+		if err != nil {
+			return nil, err
+		}
+		callbacks = append(callbacks, cb)
+	}
+
+	return callbacks, rows.Err()
+}
+
+// resumeDueCallback reopens an on-hold session whose callback is due. The agent
+// who scheduled it gets it first; anyone else may take it once the grace period passes.
+func resumeDueCallback(ctx context.Context, tx pgx.Tx, userID int, session *models.ValidationSession) (bool, error) {
+	var sessionID int
+	err := tx.QueryRow(ctx, `
+		SELECT id
+		FROM validation_sessions
+		WHERE status = 'on_hold'
+		  AND callback_at <= CURRENT_TIMESTAMP
+		  AND (user_id = $1
+		       OR callback_at <= CURRENT_TIMESTAMP - $2::integer * INTERVAL '1 second')
+		ORDER BY (user_id = $1) DESC, callback_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`, userID, int(config.CallbackGracePeriod.Seconds())).Scan(&sessionID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	err = scanSession(tx.QueryRow(ctx, `
+		UPDATE validation_sessions
+		SET status = 'in_progress',
+		    validation_results = validation_results || jsonb_build_object(
+		        'resumed_at', CURRENT_TIMESTAMP,
+		        'resumed_by', $1::integer,
+		        'held_by', user_id
+		    ),
+		    user_id = $1,
+		    locked_at = CURRENT_TIMESTAMP, locked_by = $1,
+		    updated_by = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2
+		RETURNING `+sessionColumns+`
+	`, userID, sessionID), session)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
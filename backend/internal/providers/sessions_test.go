@@ -0,0 +1,128 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/calendar"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/testdb"
+)
+
+func TestResumeDueCallbackKeepsPartialSessionOpen(t *testing.T) {
+	testdb.Open(t)
+	ctx := context.Background()
+
+	userID := seedUser(t, "agent@example.com")
+	// One of two addresses and one of two phones validated before the hold
+	providerID := seedProvider(t, 1, 2, 2, 1)
+
+	var sessionID int
+	err := database.QueryRow(ctx, `
+		INSERT INTO validation_sessions (provider_id, user_id, status, callback_at)
+		VALUES ($1, $2, 'on_hold', CURRENT_TIMESTAMP - INTERVAL '1 minute')
+		RETURNING id
+	`, providerID, userID).Scan(&sessionID)
+	if err != nil {
+		t.Fatalf("seed session: %v", err)
+	}
+
+	var session models.ValidationSession
+	var resumed bool
+	err = database.WithTx(ctx, func(tx pgx.Tx) error {
+		var err error
+		resumed, err = resumeDueCallback(ctx, tx, userID, &session)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("resumeDueCallback: %v", err)
+	}
+	if !resumed || session.ID != sessionID {
+		t.Fatalf("resumed = %v, session %d; want session %d resumed", resumed, session.ID, sessionID)
+	}
+	if session.Status != "in_progress" {
+		t.Errorf("resumed session status = %q, want in_progress", session.Status)
+	}
+
+	var status string
+	var completed bool
+	err = database.QueryRow(ctx, `
+		SELECT status, completed_at IS NOT NULL FROM validation_sessions WHERE id = $1
+	`, sessionID).Scan(&status, &completed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != "in_progress" || completed {
+		t.Errorf("stored session status = %q, completed = %v; want in_progress and not completed", status, completed)
+	}
+
+	// Later updates of the resumed session must not complete it either
+	if _, err := HeartbeatSession(sessionID, userID); err != nil {
+		t.Fatalf("HeartbeatSession: %v", err)
+	}
+	if got := sessionStatus(t, sessionID); got != "in_progress" {
+		t.Errorf("session status after heartbeat = %q, want in_progress", got)
+	}
+}
+
+func TestHeartbeatKeepsPartialSessionOpen(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+
+	userID := seedUser(t, "agent@example.com")
+	seedProvider(t, 1, 2, 2, 0)
+
+	sessionID := claimPartially(t, userID).ValidationSession.ID
+	if _, err := HeartbeatSession(sessionID, userID); err != nil {
+		t.Fatalf("HeartbeatSession: %v", err)
+	}
+	if got := sessionStatus(t, sessionID); got != "in_progress" {
+		t.Errorf("session status after heartbeat = %q, want in_progress", got)
+	}
+}
+
+// nextUTC returns the first time after two days from now that falls on
+// weekday at hour:30 UTC and satisfies ok
+func nextUTC(weekday time.Weekday, hour int, ok func(time.Time) bool) time.Time {
+	t := time.Now().UTC().AddDate(0, 0, 2)
+	t = time.Date(t.Year(), t.Month(), t.Day(), hour, 30, 0, 0, time.UTC)
+	for t.Weekday() != weekday || !ok(t) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+func TestHoldSessionUsesProviderTimeZone(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+
+	userID := seedUser(t, "agent@example.com")
+	// Addresses in Springfield, IL: the office keeps Chicago time
+	seedProvider(t, 1, 1, 1, 0)
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	sessionID := data.ValidationSession.ID
+
+	// Monday 00:30 UTC is Sunday evening in Springfield
+	sunday := nextUTC(time.Monday, 0, func(time.Time) bool { return true })
+	err = HoldSession(sessionID, userID, models.HoldSessionRequest{CallbackAt: sunday})
+	if err != ErrCallbackNotBusinessDay {
+		t.Errorf("HoldSession(%s) error = %v, want ErrCallbackNotBusinessDay", sunday, err)
+	}
+
+	// Saturday 03:30 UTC is Friday evening in Springfield
+	friday := nextUTC(time.Saturday, 3, func(t time.Time) bool {
+		return calendar.New(nil).IsBusinessDay(t.AddDate(0, 0, -1))
+	})
+	if err := HoldSession(sessionID, userID, models.HoldSessionRequest{CallbackAt: friday}); err != nil {
+		t.Errorf("HoldSession(%s): %v", friday, err)
+	}
+	if got := sessionStatus(t, sessionID); got != "on_hold" {
+		t.Errorf("session status = %q, want on_hold", got)
+	}
+}
@@ -0,0 +1,164 @@
+package providers
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// Skill types an agent can be tagged with
+const (
+	SkillState     = "state"
+	SkillLanguage  = "language"
+	SkillSpecialty = "specialty"
+)
+
+// Routing labels recorded on new sessions
+const (
+	RoutingOpen       = "open"        // agent has no skills
+	RoutingSkillMatch = "skill_match" // provider matched the agent's skills
+	RoutingFallback   = "fallback"    // skill queue was empty
+)
+
+var stateCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// skillMatchCondition restricts work_queue rows wq to those matching user $1:
+// an address state or the specialty must match one of the agent's skills, and
+// a provider that lists languages must share one with the agent.
+const skillMatchCondition = `(
+	(wq.states && ARRAY(
+		SELECT us.skill_value::text FROM user_skills us
+		WHERE us.user_id = $1 AND us.skill_type = 'state'
+	)
+	OR wq.specialty IN (
+		SELECT us.skill_value::text FROM user_skills us
+		WHERE us.user_id = $1 AND us.skill_type = 'specialty'
+	))
+	AND (cardinality(wq.languages) = 0 OR wq.languages && ARRAY(
+		SELECT us.skill_value::text FROM user_skills us
+		WHERE us.user_id = $1 AND us.skill_type = 'language'
+	))
+)`
+
+// claimProviderForUser claims the next provider, routing by the agent's skills
+// and falling back to the general queue when configured to
+func claimProviderForUser(ctx context.Context, tx pgx.Tx, userID int, provider *models.Provider) (string, error) {
+	var hasSkills bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM user_skills WHERE user_id = $1)
+	`, userID).Scan(&hasSkills)
+	if err != nil {
+		return "", err
+	}
+
+	if !hasSkills {
+		return RoutingOpen, claimNextProvider(ctx, tx, userID, false, provider)
+	}
+
+	err = claimNextProvider(ctx, tx, userID, true, provider)
+	if err == pgx.ErrNoRows && config.SkillRoutingFallback {
+		return RoutingFallback, claimNextProvider(ctx, tx, userID, false, provider)
+	}
+
+	return RoutingSkillMatch, err
+}
+
+// normalizeSkill validates a skill and returns it in its stored form
+func normalizeSkill(skill models.UserSkillInput) (models.UserSkillInput, error) {
+	value := strings.TrimSpace(skill.SkillValue)
+	switch skill.SkillType {
+	case SkillState:
+		value = strings.ToUpper(value)
+		if !stateCodePattern.MatchString(value) {
+			return skill, ErrInvalidSkill
+		}
+	case SkillLanguage, SkillSpecialty:
+		value = strings.ToLower(value)
+		if value == "" {
+			return skill, ErrInvalidSkill
+		}
+	default:
+		return skill, ErrInvalidSkill
+	}
+
+	skill.SkillValue = value
+	return skill, nil
+}
+
+// GetUserSkills lists the skills assigned to an agent
+func GetUserSkills(userID int) ([]models.UserSkill, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT id, user_id, skill_type, skill_value, created_at, created_by
+		FROM user_skills
+		WHERE user_id = $1
+		ORDER BY skill_type, skill_value
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []models.UserSkill{}
+	for rows.Next() {
+		var skill models.UserSkill
+		err := rows.Scan(
+			&skill.ID, &skill.UserID, &skill.SkillType, &skill.SkillValue,
+			&skill.CreatedAt, &skill.CreatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		skills = append(skills, skill)
+	}
+
+	return skills, rows.Err()
+}
+
+// SetUserSkills replaces an agent's skills with the given set
+func SetUserSkills(userID int, adminID int, update models.UserSkillsUpdate) error {
+	skills := make([]models.UserSkillInput, 0, len(update.Skills))
+	for _, skill := range update.Skills {
+		normalized, err := normalizeSkill(skill)
+		if err != nil {
+			return err
+		}
+		skills = append(skills, normalized)
+	}
+
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var exists bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrUserNotFound
+		}
+
+		_, err = tx.Exec(ctx, `DELETE FROM user_skills WHERE user_id = $1`, userID)
+		if err != nil {
+			return err
+		}
+
+		for _, skill := range skills {
+			_, err = tx.Exec(ctx, `
+				INSERT INTO user_skills (user_id, skill_type, skill_value, created_by)
+				VALUES ($1, $2, $3, $4)
+				ON CONFLICT (user_id, skill_type, skill_value) DO NOTHING
+			`, userID, skill.SkillType, skill.SkillValue, adminID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+package providers
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/testdb"
+)
+
+// seedRoutedProvider adds a provider with its address in state and the given
+// spoken languages
+func seedRoutedProvider(t *testing.T, n int, state string, languages ...string) int {
+	t.Helper()
+	ctx := context.Background()
+	id := seedProvider(t, n, 1, 1, 0)
+	err := database.Exec(ctx, `UPDATE provider_addresses SET state = $1 WHERE provider_id = $2`, state, id)
+	if err != nil {
+		t.Fatalf("set state of provider %d: %v", n, err)
+	}
+	if len(languages) > 0 {
+		err = database.Exec(ctx, `
+			UPDATE providers SET metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('languages', $1::text[])
+			WHERE id = $2
+		`, languages, id)
+		if err != nil {
+			t.Fatalf("set languages of provider %d: %v", n, err)
+		}
+	}
+	return id
+}
+
+func TestSkillRoutingRequiresStateOrSpecialtyAndLanguage(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+	previous := config
+	c := *config
+	c.SkillRoutingFallback = false
+	SetConfig(&c)
+	t.Cleanup(func() { SetConfig(previous) })
+
+	userID := seedUser(t, "agent@example.com")
+	err := SetUserSkills(userID, userID, models.UserSkillsUpdate{Skills: []models.UserSkillInput{
+		{SkillType: SkillState, SkillValue: "IL"},
+		{SkillType: SkillLanguage, SkillValue: "Spanish"},
+	}})
+	if err != nil {
+		t.Fatalf("SetUserSkills: %v", err)
+	}
+
+	want := []int{
+		seedRoutedProvider(t, 1, "IL"),
+		seedRoutedProvider(t, 2, "IL", "Spanish", "English"),
+	}
+	seedRoutedProvider(t, 3, "IL", "French")  // no shared language
+	seedRoutedProvider(t, 4, "TX", "Spanish") // language alone is not enough
+	seedRoutedProvider(t, 5, "TX")
+
+	var got []int
+	for {
+		data, err := GetNextProvider(userID)
+		if err == ErrNoProvidersAvailable {
+			break
+		}
+		if err != nil {
+			t.Fatalf("GetNextProvider: %v", err)
+		}
+		if routing := data.ValidationSession.ValidationResults["routing"]; routing != RoutingSkillMatch {
+			t.Errorf("provider %d routed as %v, want %s", data.Provider.ID, routing, RoutingSkillMatch)
+		}
+		got = append(got, data.Provider.ID)
+		if err := finishProvider(data, userID); err != nil {
+			t.Fatalf("finish provider %d: %v", data.Provider.ID, err)
+		}
+	}
+
+	sort.Ints(got)
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("claimed providers %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package providers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/auth"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// SupervisorReleaseReason is recorded in validation_results when a supervisor
+// force-releases another agent's session
+const SupervisorReleaseReason = "supervisor_release"
+
+// authorizeSessionUser allows the session owner, or a supervisor acting on
+// another agent's behalf. It reports whether a supervisor override was used.
+func authorizeSessionUser(sessionUserID, userID int) (bool, error) {
+	if sessionUserID == userID {
+		return false, nil
+	}
+
+	user, err := auth.GetUserByID(userID)
+	if err != nil || !auth.IsSupervisor(user) {
+		return false, ErrSessionLocked
+	}
+	return true, nil
+}
+
+// ListActiveSessions returns every in-progress session with its agent,
+// provider and lock age, oldest lock first
+func ListActiveSessions() ([]models.ActiveSession, error) {
+	ctx := context.Background()
+
+	rows, err := database.Query(ctx, `
+		SELECT vs.id, vs.provider_id, p.npi, p.provider_name,
+		       vs.user_id, u.email, u.first_name, u.last_name,
+		       vs.started_at, vs.locked_at,
+		       EXTRACT(EPOCH FROM CURRENT_TIMESTAMP - vs.locked_at)::integer
+		FROM validation_sessions vs
+		JOIN providers p ON p.id = vs.provider_id
+		JOIN users u ON u.id = vs.user_id
+		WHERE vs.status = 'in_progress'
+		ORDER BY vs.locked_at, vs.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []models.ActiveSession{}
+	for rows.Next() {
+		var s models.ActiveSession
+		err := rows.Scan(
+			&s.SessionID, &s.ProviderID, &s.NPI, &s.ProviderName,
+			&s.UserID, &s.AgentEmail, &s.AgentFirstName, &s.AgentLastName,
+			&s.StartedAt, &s.LockedAt, &s.LockAgeSeconds,
+		)
+		if err != nil {
+			return nil, err
+		}
+		s.LockExpiresAt = s.LockedAt.Add(config.SessionTTL)
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+// ForceReleaseSession cancels another agent's in-progress session and returns
+// the provider to the queue. Partial validations are kept.
+func ForceReleaseSession(sessionID int, supervisorID int, req models.ForceReleaseRequest) error {
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var status string
+		var sessionUserID int
+		err := tx.QueryRow(ctx, `
+			SELECT status, user_id
+			FROM validation_sessions
+			WHERE id = $1
+			FOR UPDATE
+		`, sessionID).Scan(&status, &sessionUserID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if status != "in_progress" {
+			return ErrSessionNotActive
+		}
+
+		releaseResults := map[string]interface{}{
+			"released_at":      time.Now(),
+			"released_by":      supervisorID,
+			"released_from":    sessionUserID,
+			"release_reason":   SupervisorReleaseReason,
+			"exclude_releaser": false,
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE validation_sessions
+			SET status = 'cancelled',
+			    locked_by = NULL,
+			    notes = COALESCE($1, notes),
+			    validation_results = validation_results || $2::jsonb,
+			    updated_by = $3, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $4
+		`, nullStringValue(req.Notes), releaseResults, supervisorID, sessionID)
+
+		return err
+	})
+}
+
+// ReassignSession hands an in-progress or on-hold session to another agent.
+// The handoff note is appended to the session notes and the handoff is kept
+// in validation_results so the new agent can see where the call left off.
+func ReassignSession(sessionID int, supervisorID int, req models.ReassignSessionRequest) error {
+	if req.HandoffNote == "" {
+		return ErrHandoffNoteRequired
+	}
+
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var status string
+		var sessionUserID int
+		err := tx.QueryRow(ctx, `
+			SELECT status, user_id
+			FROM validation_sessions
+			WHERE id = $1
+			FOR UPDATE
+		`, sessionID).Scan(&status, &sessionUserID)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if status != "in_progress" && status != "on_hold" {
+			return ErrSessionNotActive
+		}
+
+		var isActive bool
+		err = tx.QueryRow(ctx, `SELECT is_active FROM users WHERE id = $1`, req.UserID).Scan(&isActive)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrUserNotFound
+			}
+			return err
+		}
+		if !isActive {
+			return ErrUserNotFound
+		}
+
+		// GetNextProvider resumes an agent's single in-progress session, so
+		// an agent already working a provider cannot take on another
+		if status == "in_progress" {
+			var busy bool
+			err = tx.QueryRow(ctx, `
+				SELECT EXISTS (
+					SELECT 1 FROM validation_sessions
+					WHERE user_id = $1 AND status = 'in_progress' AND id <> $2
+				)
+			`, req.UserID, sessionID).Scan(&busy)
+			if err != nil {
+				return err
+			}
+			if busy {
+				return ErrAgentBusy
+			}
+		}
+
+		handoff := map[string]interface{}{
+			"from_user_id":  sessionUserID,
+			"to_user_id":    req.UserID,
+			"reassigned_by": supervisorID,
+			"reassigned_at": time.Now(),
+			"note":          req.HandoffNote,
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE validation_sessions
+			SET user_id = $1,
+			    locked_by = CASE WHEN status = 'in_progress' THEN $1 END,
+			    locked_at = CURRENT_TIMESTAMP,
+			    notes = concat_ws(E'\n', notes, 'Handoff: ' || $2),
+			    validation_results = validation_results || jsonb_build_object(
+			        'handoffs', COALESCE(validation_results->'handoffs', '[]'::jsonb) || jsonb_build_array($3::jsonb)
+			    ),
+			    updated_by = $4, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $5
+		`, req.UserID, req.HandoffNote, handoff, supervisorID, sessionID)
+
+		return err
+	})
+}
@@ -0,0 +1,77 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/user/auth-app/internal/auth"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/testdb"
+)
+
+// seedSupervisor registers a user with the supervisor role and returns its ID
+func seedSupervisor(t testing.TB, email string) int {
+	t.Helper()
+	id := seedUser(t, email)
+	err := database.Exec(context.Background(),
+		`UPDATE users SET role = $1 WHERE id = $2`, auth.RoleSupervisor, id)
+	if err != nil {
+		t.Fatalf("grant supervisor to %s: %v", email, err)
+	}
+	return id
+}
+
+func TestForceReleasedPartialProviderCanBeReclaimed(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+
+	agentID := seedUser(t, "agent@example.com")
+	supervisorID := seedSupervisor(t, "supervisor@example.com")
+	providerID := seedProvider(t, 1, 2, 2, 0)
+
+	data := claimPartially(t, agentID)
+	sessionID := data.ValidationSession.ID
+	err := ForceReleaseSession(sessionID, supervisorID, models.ForceReleaseRequest{Notes: "agent left"})
+	if err != nil {
+		t.Fatalf("ForceReleaseSession: %v", err)
+	}
+	if got := sessionStatus(t, sessionID); got != "cancelled" {
+		t.Fatalf("force-released session status = %q, want cancelled", got)
+	}
+
+	expectReclaimable(t, providerID, agentID)
+}
+
+func TestSupervisorCompletesAgentSession(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+
+	agentID := seedUser(t, "agent@example.com")
+	otherID := seedUser(t, "other@example.com")
+	supervisorID := seedSupervisor(t, "supervisor@example.com")
+	seedProvider(t, 1, 1, 1, 0)
+
+	data, err := GetNextProvider(agentID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	sessionID := data.ValidationSession.ID
+	update := models.ValidationUpdate{
+		AddressValidations: []models.AddressValidation{{AddressID: data.Addresses[0].ID, IsCorrect: true}},
+		PhoneValidations:   []models.PhoneValidation{{PhoneID: data.Phones[0].ID, IsCorrect: true}},
+	}
+	if _, err := UpdateValidation(sessionID, agentID, update); err != nil {
+		t.Fatalf("UpdateValidation: %v", err)
+	}
+
+	if err := CompleteValidation(sessionID, otherID); err != ErrSessionLocked {
+		t.Fatalf("another agent completing: err = %v, want ErrSessionLocked", err)
+	}
+	if err := CompleteValidation(sessionID, supervisorID); err != nil {
+		t.Fatalf("supervisor completing: %v", err)
+	}
+	if got := sessionStatus(t, sessionID); got != "completed" {
+		t.Errorf("session status = %q, want completed", got)
+	}
+}
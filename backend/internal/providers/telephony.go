@@ -0,0 +1,401 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/telephony"
+)
+
+// Telephony call statuses, matching the valid_call_status constraint
+const (
+	CallStatusDialing  = "dialing"
+	CallStatusRinging  = "ringing"
+	CallStatusAnswered = "answered"
+	CallStatusEnded    = "ended"
+	CallStatusFailed   = "failed"
+)
+
+// dialer places click-to-dial calls; nil disables telephony
+var dialer telephony.Dialer
+
+// SetDialer installs the telephony integration
+func SetDialer(d telephony.Dialer) {
+	dialer = d
+}
+
+// hangupOutcomes maps carrier hangup causes of unanswered calls to attempt outcomes
+var hangupOutcomes = map[string]string{
+	telephony.CauseNoAnswer:      OutcomeNoAnswer,
+	telephony.CauseBusy:          OutcomeBusy,
+	telephony.CauseFailed:        OutcomeDisconnected,
+	telephony.CauseInvalidNumber: OutcomeInvalid,
+}
+
+// CallExpiredError is recorded on calls that never reported a hangup
+const CallExpiredError = "no hangup event within the maximum call duration"
+
+// staleCallsCondition matches live calls dialed, or answered, more than $1
+// seconds ago. A lost hangup event would otherwise keep the call live and
+// block further calls on its session.
+const staleCallsCondition = `status IN ('dialing', 'ringing', 'answered')
+	AND COALESCE(answered_at, created_at) < CURRENT_TIMESTAMP - $1::integer * INTERVAL '1 second'`
+
+// recordableCallErrors leave the call unrecorded instead of failing the webhook;
+// retrying the event would not change the answer
+var recordableCallErrors = map[error]bool{
+	pgx.ErrNoRows:           true,
+	ErrSessionLocked:        true,
+	ErrInvalidCallAttempt:   true,
+	ErrAttemptTooSoon:       true,
+	ErrOutcomeNotAllowed:    true,
+	ErrOutsideCallingWindow: true,
+	ErrPhoneNotFound:        true,
+}
+
+// callColumns matches scanCall
+const callColumns = `id, session_id, phone_id, user_id, attempt_number, dialer, dialed_number,
+	status, outcome::text, hangup_cause, duration, ringing_at, answered_at, ended_at,
+	record_error, created_at`
+
+// isLiveCallConflict reports whether an insert failed because the session
+// already has a call in progress
+func isLiveCallConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) &&
+		pgErr.Code == "23505" &&
+		pgErr.ConstraintName == "idx_telephony_calls_live"
+}
+
+func scanCall(row pgx.Row, call *models.TelephonyCall) error {
+	return row.Scan(
+		&call.ID, &call.SessionID, &call.PhoneID, &call.UserID, &call.AttemptNumber,
+		&call.Dialer, &call.DialedNumber, &call.Status, &call.Outcome, &call.HangupCause,
+		&call.Duration, &call.RingingAt, &call.AnsweredAt, &call.EndedAt,
+		&call.RecordError, &call.CreatedAt,
+	)
+}
+
+// StartCall dials one of the session's phones. The call attempt is recorded
+// when the carrier reports the hangup, so the policy is checked up front.
+func StartCall(sessionID int, userID int, req models.DialRequest) (*models.TelephonyCall, error) {
+	if dialer == nil {
+		return nil, ErrTelephonyDisabled
+	}
+
+	ctx := context.Background()
+
+	call := &models.TelephonyCall{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		PhoneID:   req.PhoneID,
+		UserID:    userID,
+		Dialer:    dialer.Name(),
+		Status:    CallStatusDialing,
+	}
+	err := database.WithTx(ctx, func(tx pgx.Tx) error {
+		var sessionUserID, providerID int
+		var attempts []models.CallAttemptRecord
+		err := tx.QueryRow(ctx, `
+			SELECT user_id, provider_id, call_attempts
+			FROM validation_sessions
+			WHERE id = $1 AND status = 'in_progress'
+			FOR UPDATE
+		`, sessionID).Scan(&sessionUserID, &providerID, &attempts)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrSessionNotFound
+			}
+			return err
+		}
+		if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+			return err
+		}
+
+		// Dial the corrected number when the agent has already fixed it
+		err = tx.QueryRow(ctx, `
+			SELECT COALESCE(corrected_phone, phone) FROM provider_phones WHERE id = $1 AND provider_id = $2
+		`, req.PhoneID, providerID).Scan(&call.DialedNumber)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrPhoneNotFound
+			}
+			return err
+		}
+
+		localTime, err := providerLocalTime(ctx, tx, providerID)
+		if err != nil {
+			return err
+		}
+		if !localTime.WithinCallingWindow && config.CallWindowRefuse {
+			return ErrOutsideCallingWindow
+		}
+
+		policy, err := loadCallAttemptPolicy(ctx, tx, providerID)
+		if err != nil {
+			return err
+		}
+		cal, err := loadProviderCalendar(ctx, tx, providerID)
+		if err != nil {
+			return err
+		}
+		status := callAttemptStatus(sessionID, policy, cal, attempts)
+		call.AttemptNumber = req.AttemptNumber
+		if call.AttemptNumber == 0 {
+			call.AttemptNumber = status.NextAttemptNumber
+		}
+		if call.AttemptNumber < 1 || call.AttemptNumber > policy.MaxAttempts || call.AttemptNumber > status.AttemptsMade+1 {
+			return ErrInvalidCallAttempt
+		}
+		if allowedAt := attemptAllowedAt(policy, cal, attempts, call.AttemptNumber); time.Now().Before(allowedAt) {
+			return ErrAttemptTooSoon
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE telephony_calls
+			SET status = $2, ended_at = CURRENT_TIMESTAMP, record_error = $3
+			WHERE session_id = $4 AND `+staleCallsCondition+`
+		`, int(config.CallMaxDuration.Seconds()), CallStatusFailed, CallExpiredError, sessionID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, `
+			INSERT INTO telephony_calls (id, session_id, phone_id, user_id, attempt_number, dialer, dialed_number, status)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING created_at
+		`, call.ID, sessionID, req.PhoneID, userID, call.AttemptNumber, call.Dialer, call.DialedNumber, call.Status).Scan(&call.CreatedAt)
+		if err != nil {
+			if isLiveCallConflict(err) {
+				return ErrCallInProgress
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The carrier is called outside the transaction; a failed dial frees the session for another call
+	if err := dialer.Dial(ctx, telephony.Call{ID: call.ID.String(), To: call.DialedNumber}); err != nil {
+		log.Printf("StartCall: %s dialer failed for call %s: %v", call.Dialer, call.ID, err)
+		dbErr := database.Exec(ctx, `
+			UPDATE telephony_calls SET status = $1, ended_at = CURRENT_TIMESTAMP, record_error = $2
+			WHERE id = $3
+		`, CallStatusFailed, err.Error(), call.ID)
+		if dbErr != nil {
+			return nil, dbErr
+		}
+		return nil, ErrDialFailed
+	}
+
+	return call, nil
+}
+
+// HangupCall asks the carrier to end a call in progress
+func HangupCall(callID uuid.UUID, userID int) error {
+	if dialer == nil {
+		return ErrTelephonyDisabled
+	}
+
+	ctx := context.Background()
+
+	var call models.TelephonyCall
+	err := scanCall(database.QueryRow(ctx, `
+		SELECT `+callColumns+` FROM telephony_calls WHERE id = $1
+	`, callID), &call)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return ErrCallNotFound
+		}
+		return err
+	}
+	if _, err := authorizeSessionUser(call.UserID, userID); err != nil {
+		return err
+	}
+	if call.Status == CallStatusEnded || call.Status == CallStatusFailed {
+		return ErrCallNotFound
+	}
+
+	return dialer.Hangup(ctx, callID.String())
+}
+
+// ListSessionCalls returns the session's telephony calls, newest first
+func ListSessionCalls(sessionID int, userID int) ([]models.TelephonyCall, error) {
+	ctx := context.Background()
+
+	var sessionUserID int
+	err := database.QueryRow(ctx, `SELECT user_id FROM validation_sessions WHERE id = $1`, sessionID).Scan(&sessionUserID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	if _, err := authorizeSessionUser(sessionUserID, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := database.Query(ctx, `
+		SELECT `+callColumns+` FROM telephony_calls WHERE session_id = $1 ORDER BY created_at DESC
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	calls := []models.TelephonyCall{}
+	for rows.Next() {
+		var call models.TelephonyCall
+		if err := scanCall(rows, &call); err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+
+	return calls, rows.Err()
+}
+
+// ExpireStaleCalls fails live calls whose hangup event never arrived within
+// maxDuration, so their sessions can place calls again. No attempt is recorded.
+func ExpireStaleCalls(ctx context.Context, maxDuration time.Duration) (int, error) {
+	rows, err := database.Query(ctx, `
+		UPDATE telephony_calls
+		SET status = $2, ended_at = CURRENT_TIMESTAMP, record_error = $3
+		WHERE `+staleCallsCondition+`
+		RETURNING id, session_id
+	`, int(maxDuration.Seconds()), CallStatusFailed, CallExpiredError)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	expired := 0
+	for rows.Next() {
+		var callID uuid.UUID
+		var sessionID int
+		if err := rows.Scan(&callID, &sessionID); err != nil {
+			return expired, err
+		}
+		log.Printf("Expired stale call %s (session %d)", callID, sessionID)
+		expired++
+	}
+
+	return expired, rows.Err()
+}
+
+// ParseCallEvent authenticates a carrier webhook with the active dialer
+func ParseCallEvent(r *http.Request) (*telephony.Event, error) {
+	if dialer == nil {
+		return nil, ErrTelephonyDisabled
+	}
+	return dialer.ParseEvent(r)
+}
+
+// HandleCallEvent applies a carrier event to its call. The hangup event
+// records the call attempt on the session, once, however often it is delivered.
+func HandleCallEvent(event *telephony.Event) error {
+	callID, err := uuid.Parse(event.CallID)
+	if err != nil {
+		return ErrCallNotFound
+	}
+
+	ctx := context.Background()
+
+	return database.WithTx(ctx, func(tx pgx.Tx) error {
+		var call models.TelephonyCall
+		err := scanCall(tx.QueryRow(ctx, `
+			SELECT `+callColumns+` FROM telephony_calls WHERE id = $1 FOR UPDATE
+		`, callID), &call)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return ErrCallNotFound
+			}
+			return err
+		}
+		// Late or repeated events for a finished call are ignored
+		if call.Status == CallStatusEnded || call.Status == CallStatusFailed {
+			return nil
+		}
+
+		switch event.Type {
+		case telephony.EventRinging:
+			_, err = tx.Exec(ctx, `
+				UPDATE telephony_calls SET status = $1, ringing_at = $2
+				WHERE id = $3 AND status = $4
+			`, CallStatusRinging, event.Timestamp, callID, CallStatusDialing)
+			return err
+
+		case telephony.EventAnswered:
+			_, err = tx.Exec(ctx, `
+				UPDATE telephony_calls SET status = $1, answered_at = $2
+				WHERE id = $3
+			`, CallStatusAnswered, event.Timestamp, callID)
+			return err
+		}
+
+		// The agent hung up before anyone answered. That says nothing about
+		// the provider, so no attempt is recorded.
+		if !call.AnsweredAt.Valid && event.Cause == telephony.CauseCanceled {
+			_, err = tx.Exec(ctx, `
+				UPDATE telephony_calls
+				SET status = $1, hangup_cause = $2, duration = 0, ended_at = $3
+				WHERE id = $4
+			`, CallStatusEnded, event.Cause, event.Timestamp, callID)
+			return err
+		}
+
+		// Hangup: an answered call is a successful attempt whatever the cause
+		outcome := OutcomeSuccessful
+		if !call.AnsweredAt.Valid {
+			outcome = hangupOutcomes[event.Cause]
+			if outcome == "" {
+				outcome = OutcomeNoAnswer
+			}
+		}
+		duration := event.Duration
+		if duration == 0 && call.AnsweredAt.Valid {
+			duration = int(event.Timestamp.Sub(call.AnsweredAt.Time).Round(time.Second).Seconds())
+		}
+		if duration < 0 {
+			duration = 0
+		}
+
+		var recordError *string
+		_, err = recordCallAttempt(ctx, tx, call.SessionID, call.UserID, models.CallAttemptRequest{
+			AttemptNumber: call.AttemptNumber,
+			PhoneID:       call.PhoneID,
+			Outcome:       outcome,
+			Duration:      duration,
+			Notes:         fmt.Sprintf("Call via %s (%s)", call.Dialer, event.Cause),
+			CallID:        call.ID.String(),
+		})
+		if err != nil {
+			if !recordableCallErrors[err] {
+				return err
+			}
+			// The session moved on (completed, reassigned, ...); keep the call for reference
+			msg := err.Error()
+			recordError = &msg
+			log.Printf("HandleCallEvent: call %s not recorded on session %d: %v", call.ID, call.SessionID, err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE telephony_calls
+			SET status = $1, outcome = $2::call_attempt_status, hangup_cause = $3,
+			    duration = $4, ended_at = $5, record_error = $6
+			WHERE id = $7
+		`, CallStatusEnded, outcome, nullStringValue(event.Cause), duration, event.Timestamp, recordError, callID)
+		return err
+	})
+}
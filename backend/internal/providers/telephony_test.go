@@ -0,0 +1,262 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+	"github.com/user/auth-app/internal/telephony"
+	"github.com/user/auth-app/internal/testdb"
+)
+
+// useFakeDialer installs a fake dialer whose events go through the webhook
+// path: ParseCallEvent, then HandleCallEvent
+func useFakeDialer(t *testing.T) *telephony.FakeDialer {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := ParseCallEvent(r)
+		if err != nil {
+			t.Errorf("ParseCallEvent: %v", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if err := HandleCallEvent(event); err != nil {
+			t.Errorf("HandleCallEvent(%s): %v", event.Type, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	d := telephony.NewFakeDialer(srv.URL, "webhook-secret")
+	d.RingDelay = 10 * time.Millisecond
+	d.AnswerDelay = 10 * time.Millisecond
+	d.TalkTime = 1100 * time.Millisecond
+	SetDialer(d)
+	t.Cleanup(func() { SetDialer(nil) })
+	return d
+}
+
+// phoneEndingIn picks the claimed phone whose number ends in digit, which
+// selects the fake dialer's outcome
+func phoneEndingIn(t *testing.T, data *models.ProviderValidationData, digit string) int {
+	t.Helper()
+	for _, p := range data.Phones {
+		if strings.HasSuffix(p.Phone, digit) {
+			return p.ID
+		}
+	}
+	t.Fatalf("no phone ending in %s", digit)
+	return 0
+}
+
+// waitForCallEnd polls until the carrier's hangup has been applied
+func waitForCallEnd(t *testing.T, callID uuid.UUID) models.TelephonyCall {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var call models.TelephonyCall
+		err := scanCall(database.QueryRow(context.Background(), `
+			SELECT `+callColumns+` FROM telephony_calls WHERE id = $1
+		`, callID), &call)
+		if err != nil {
+			t.Fatalf("read call %s: %v", callID, err)
+		}
+		if call.Status == CallStatusEnded || call.Status == CallStatusFailed {
+			return call
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("call %s still %s", callID, call.Status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// sessionAttempts reads the call attempts recorded on a session
+func sessionAttempts(t *testing.T, sessionID int) []models.CallAttemptRecord {
+	t.Helper()
+	var attempts []models.CallAttemptRecord
+	err := database.QueryRow(context.Background(),
+		`SELECT call_attempts FROM validation_sessions WHERE id = $1`, sessionID).Scan(&attempts)
+	if err != nil {
+		t.Fatalf("read attempts of session %d: %v", sessionID, err)
+	}
+	return attempts
+}
+
+func TestAnsweredCallRecordsAttempt(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+	useFakeDialer(t)
+
+	userID := seedUser(t, "agent@example.com")
+	seedProvider(t, 1, 1, 3, 0)
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	sessionID := data.ValidationSession.ID
+	phoneID := phoneEndingIn(t, data, "2")
+
+	started, err := StartCall(sessionID, userID, models.DialRequest{PhoneID: phoneID})
+	if err != nil {
+		t.Fatalf("StartCall: %v", err)
+	}
+	call := waitForCallEnd(t, started.ID)
+
+	if call.Status != CallStatusEnded || !call.RingingAt.Valid || !call.AnsweredAt.Valid {
+		t.Errorf("call = status %s, ringing %v, answered %v; want ended after ringing and answering",
+			call.Status, call.RingingAt.Valid, call.AnsweredAt.Valid)
+	}
+	if call.Outcome.String != OutcomeSuccessful || call.HangupCause.String != telephony.CauseCompleted {
+		t.Errorf("call outcome = %q, cause %q; want %s, %s",
+			call.Outcome.String, call.HangupCause.String, OutcomeSuccessful, telephony.CauseCompleted)
+	}
+	if call.Duration.Int64 != 1 {
+		t.Errorf("call duration = %d, want 1", call.Duration.Int64)
+	}
+
+	attempts := sessionAttempts(t, sessionID)
+	if len(attempts) != 1 {
+		t.Fatalf("recorded %d attempts, want 1", len(attempts))
+	}
+	a := attempts[0]
+	if a.AttemptNumber != 1 || a.Status != OutcomeSuccessful || a.Duration != 1 ||
+		a.PhoneID != phoneID || a.CallID != started.ID.String() {
+		t.Errorf("attempt = %+v, want attempt 1 successful for 1s on phone %d from call %s",
+			a, phoneID, started.ID)
+	}
+}
+
+func TestCanceledCallRecordsNoAttempt(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+	d := useFakeDialer(t)
+	d.RingDelay = time.Second
+
+	userID := seedUser(t, "agent@example.com")
+	seedProvider(t, 1, 1, 3, 0)
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	sessionID := data.ValidationSession.ID
+
+	started, err := StartCall(sessionID, userID, models.DialRequest{PhoneID: phoneEndingIn(t, data, "2")})
+	if err != nil {
+		t.Fatalf("StartCall: %v", err)
+	}
+	if err := HangupCall(started.ID, userID); err != nil {
+		t.Fatalf("HangupCall: %v", err)
+	}
+	call := waitForCallEnd(t, started.ID)
+
+	if call.HangupCause.String != telephony.CauseCanceled || call.Outcome.Valid {
+		t.Errorf("call cause = %q, outcome %v; want canceled with no outcome", call.HangupCause.String, call.Outcome)
+	}
+	if attempts := sessionAttempts(t, sessionID); len(attempts) != 0 {
+		t.Errorf("recorded attempts %+v for a canceled call", attempts)
+	}
+}
+
+func TestStaleCallDoesNotBlockSession(t *testing.T) {
+	testdb.Open(t)
+	withoutCallingWindow(t)
+	useFakeDialer(t)
+	ctx := context.Background()
+
+	userID := seedUser(t, "agent@example.com")
+	seedProvider(t, 1, 1, 3, 0)
+	data, err := GetNextProvider(userID)
+	if err != nil {
+		t.Fatalf("GetNextProvider: %v", err)
+	}
+	sessionID := data.ValidationSession.ID
+	phoneID := phoneEndingIn(t, data, "2")
+
+	// A call whose hangup event was lost, answered longer ago than the limit
+	lost := uuid.New()
+	err = database.Exec(ctx, `
+		INSERT INTO telephony_calls (id, session_id, phone_id, user_id, attempt_number, dialer, dialed_number,
+		                             status, answered_at, created_at)
+		VALUES ($1, $2, $3, $4, 1, 'fake', '+12175552012', $5,
+		        CURRENT_TIMESTAMP - $6::integer * INTERVAL '1 second' - INTERVAL '1 minute',
+		        CURRENT_TIMESTAMP - $6::integer * INTERVAL '1 second' - INTERVAL '2 minutes')
+	`, lost, sessionID, phoneID, userID, CallStatusAnswered, int(config.CallMaxDuration.Seconds()))
+	if err != nil {
+		t.Fatalf("seed lost call: %v", err)
+	}
+
+	started, err := StartCall(sessionID, userID, models.DialRequest{PhoneID: phoneID})
+	if err != nil {
+		t.Fatalf("StartCall with a stale call on the session: %v", err)
+	}
+	waitForCallEnd(t, started.ID)
+
+	call := waitForCallEnd(t, lost)
+	if call.Status != CallStatusFailed || call.RecordError.String != CallExpiredError {
+		t.Errorf("lost call = status %s, error %q; want failed with %q", call.Status, call.RecordError.String, CallExpiredError)
+	}
+	if attempts := sessionAttempts(t, sessionID); len(attempts) != 1 || attempts[0].CallID != started.ID.String() {
+		t.Errorf("attempts = %+v, want only the new call's", attempts)
+	}
+}
+
+func TestExpireStaleCallsKeepsLiveCalls(t *testing.T) {
+	testdb.Open(t)
+	ctx := context.Background()
+
+	userID := seedUser(t, "agent@example.com")
+	providerID := seedProvider(t, 1, 1, 1, 0)
+	var phoneID int
+	if err := database.QueryRow(ctx, `SELECT id FROM provider_phones WHERE provider_id = $1`, providerID).Scan(&phoneID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dialed three hours ago; one was answered a minute ago and is still talking
+	stale, talking := uuid.New(), uuid.New()
+	err := database.Exec(ctx, `
+		INSERT INTO telephony_calls (id, session_id, phone_id, user_id, attempt_number, dialer, dialed_number,
+		                             status, answered_at, created_at)
+		VALUES ($1, 1, $3, $4, 1, 'fake', '+12175552010', 'ringing', NULL, CURRENT_TIMESTAMP - INTERVAL '3 hours'),
+		       ($2, 2, $3, $4, 1, 'fake', '+12175552010', 'answered', CURRENT_TIMESTAMP - INTERVAL '1 minute',
+		        CURRENT_TIMESTAMP - INTERVAL '3 hours')
+	`, stale, talking, phoneID, userID)
+	if err != nil {
+		t.Fatalf("seed calls: %v", err)
+	}
+
+	expired, err := ExpireStaleCalls(ctx, 2*time.Hour)
+	if err != nil {
+		t.Fatalf("ExpireStaleCalls: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired %d calls, want 1", expired)
+	}
+	var staleStatus, talkingStatus string
+	err = database.QueryRow(ctx, `
+		SELECT (SELECT status FROM telephony_calls WHERE id = $1), (SELECT status FROM telephony_calls WHERE id = $2)
+	`, stale, talking).Scan(&staleStatus, &talkingStatus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if staleStatus != CallStatusFailed || talkingStatus != CallStatusAnswered {
+		t.Errorf("statuses = %s, %s; want failed, answered", staleStatus, talkingStatus)
+	}
+}
+
+func TestParseCallEventRejectsBadSignature(t *testing.T) {
+	useFakeDialer(t)
+
+	body := `{"call_id":"` + uuid.NewString() + `","type":"hangup","cause":"completed","duration":60}`
+	r := httptest.NewRequest(http.MethodPost, "/api/telephony/events", strings.NewReader(body))
+	r.Header.Set(telephony.SignatureHeader, telephony.Sign("guessed-secret", []byte(body)))
+	if _, err := ParseCallEvent(r); err != telephony.ErrInvalidSignature {
+		t.Errorf("ParseCallEvent() error = %v, want ErrInvalidSignature", err)
+	}
+}
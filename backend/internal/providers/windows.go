@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"context"
+	"time"
+	_ "time/tzdata" // provider time zones must resolve without system zoneinfo
+
+	"github.com/jackc/pgx/v5"
+	"github.com/user/auth-app/internal/calendar"
+	"github.com/user/auth-app/internal/database"
+	"github.com/user/auth-app/internal/models"
+)
+
+// callingWindowCondition restricts work_queue rows wq to providers whose local
+// time is inside office hours on a business day. $3 enables the check; $4 and
+// $5 bound the window and $6 lists federal holidays (see holidayDates).
+const callingWindowCondition = `(NOT $3::boolean OR within_calling_window(wq.timezone, wq.states, $4::time, $5::time, $6::date[]))`
+
+// holidayDates lists the observed federal holidays from last year through
+// next, wide enough for any provider's local date. Admin closures live in
+// business_closures, which within_calling_window reads itself.
+func holidayDates(now time.Time) []time.Time {
+	var dates []time.Time
+	for year := now.Year() - 1; year <= now.Year()+1; year++ {
+		for _, h := range calendar.FederalHolidays(year) {
+			dates = append(dates, h.Date)
+		}
+	}
+	return dates
+}
+
+// LocationTimezone returns the time zone the calling window uses for a state
+// and ZIP, or "" when the state is unknown
+func LocationTimezone(state, zip string) (string, error) {
+	var timezone models.NullString
+	err := database.QueryRow(context.Background(),
+		`SELECT location_timezone($1, $2)`, state, zip).Scan(&timezone)
+	if err != nil {
+		return "", err
+	}
+	return timezone.String, nil
+}
+
+// providerLocalTime reports the provider's local time and whether it falls
+// inside the configured calling window
+func providerLocalTime(ctx context.Context, tx pgx.Tx, providerID int) (*models.ProviderLocalTime, error) {
+	var timezone models.NullString
+	var within bool
+	err := tx.QueryRow(ctx, `
+		SELECT tz, within_calling_window(tz, ARRAY(
+		           SELECT DISTINCT UPPER(COALESCE(corrected_state, state))
+		           FROM provider_addresses
+		           WHERE provider_id = $1 AND COALESCE(corrected_state, state) IS NOT NULL
+		       ), $2::time, $3::time, $4::date[])
+		FROM provider_timezone($1) AS tz
+	`, providerID, config.CallWindowStart, config.CallWindowEnd, holidayDates(time.Now())).Scan(&timezone, &within)
+	if err != nil {
+		return nil, err
+	}
+
+	local := &models.ProviderLocalTime{
+		WithinCallingWindow: within || !config.CallWindowEnabled,
+		CallingWindowStart:  config.CallWindowStart,
+		CallingWindowEnd:    config.CallWindowEnd,
+	}
+	if !timezone.Valid {
+		return local, nil
+	}
+
+	local.Timezone = timezone.String
+	if loc, err := time.LoadLocation(timezone.String); err == nil {
+		now := time.Now().In(loc)
+		local.LocalTime = &now
+	}
+	return local, nil
+}
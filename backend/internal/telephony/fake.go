@@ -0,0 +1,162 @@
+package telephony
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeDialer simulates calls without a carrier, posting signed events to the
+// webhook like a real carrier would. The last digit of the dialed number
+// picks the outcome:
+//
+//	0  rings out unanswered
+//	1  busy
+//	8  fails to connect
+//	9  number not in service
+//	any other digit is answered and ends after TalkTime or when the agent hangs up
+type FakeDialer struct {
+	WebhookURL  string
+	Secret      string
+	RingDelay   time.Duration // dial to ringing
+	AnswerDelay time.Duration // ringing to answered, or to giving up
+	TalkTime    time.Duration // answered to hangup
+	Client      *http.Client
+
+	mu      sync.Mutex
+	hangups map[string]chan struct{}
+}
+
+// NewFakeDialer returns a fake dialer with short, development-friendly timings
+func NewFakeDialer(webhookURL, secret string) *FakeDialer {
+	return &FakeDialer{
+		WebhookURL:  webhookURL,
+		Secret:      secret,
+		RingDelay:   time.Second,
+		AnswerDelay: 4 * time.Second,
+		TalkTime:    30 * time.Second,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		hangups:     make(map[string]chan struct{}),
+	}
+}
+
+func (d *FakeDialer) Name() string { return "fake" }
+
+// Dial schedules the simulated call and returns immediately
+func (d *FakeDialer) Dial(ctx context.Context, call Call) error {
+	hangup := make(chan struct{})
+	d.mu.Lock()
+	d.hangups[call.ID] = hangup
+	d.mu.Unlock()
+
+	go d.simulate(call, hangup)
+	return nil
+}
+
+// Hangup ends a simulated call early
+func (d *FakeDialer) Hangup(ctx context.Context, callID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	hangup, ok := d.hangups[callID]
+	if !ok {
+		return ErrUnknownCall
+	}
+	close(hangup)
+	delete(d.hangups, callID)
+	return nil
+}
+
+func (d *FakeDialer) ParseEvent(r *http.Request) (*Event, error) {
+	return ParseSignedEvent(r, d.Secret)
+}
+
+func (d *FakeDialer) simulate(call Call, hangup chan struct{}) {
+	defer func() {
+		d.mu.Lock()
+		delete(d.hangups, call.ID)
+		d.mu.Unlock()
+	}()
+
+	// wait reports false if the agent hung up first
+	wait := func(delay time.Duration) bool {
+		select {
+		case <-time.After(delay):
+			return true
+		case <-hangup:
+			return false
+		}
+	}
+	end := func(cause string, duration int) {
+		d.post(Event{CallID: call.ID, Type: EventHangup, Cause: cause, Duration: duration})
+	}
+
+	digits := strings.TrimSpace(call.To)
+	last := byte('5')
+	if len(digits) > 0 {
+		last = digits[len(digits)-1]
+	}
+
+	if !wait(d.RingDelay) {
+		end(CauseCanceled, 0)
+		return
+	}
+	switch last {
+	case '9':
+		end(CauseInvalidNumber, 0)
+		return
+	case '8':
+		end(CauseFailed, 0)
+		return
+	case '1':
+		end(CauseBusy, 0)
+		return
+	}
+
+	d.post(Event{CallID: call.ID, Type: EventRinging})
+	if !wait(d.AnswerDelay) {
+		end(CauseCanceled, 0)
+		return
+	}
+	if last == '0' {
+		end(CauseNoAnswer, 0)
+		return
+	}
+
+	d.post(Event{CallID: call.ID, Type: EventAnswered})
+	answeredAt := time.Now()
+	wait(d.TalkTime)
+	end(CauseCompleted, int(time.Since(answeredAt).Round(time.Second).Seconds()))
+}
+
+func (d *FakeDialer) post(event Event) {
+	event.Timestamp = time.Now()
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Fake dialer: failed to encode event for call %s: %v", event.CallID, err)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodPost, d.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		log.Printf("Fake dialer: failed to build webhook request: %v", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(d.Secret, body))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		log.Printf("Fake dialer: failed to deliver %s event for call %s: %v", event.Type, event.CallID, err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("Fake dialer: webhook rejected %s event for call %s: %s", event.Type, event.CallID, resp.Status)
+	}
+}
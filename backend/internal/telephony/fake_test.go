@@ -0,0 +1,114 @@
+package telephony
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// recordEvents starts a webhook that verifies and collects a fake dialer's events
+func recordEvents(t *testing.T, secret string) (*FakeDialer, <-chan Event) {
+	t.Helper()
+	events := make(chan Event, 10)
+	var d *FakeDialer
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event, err := d.ParseEvent(r)
+		if err != nil {
+			t.Errorf("webhook rejected event: %v", err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		events <- *event
+	}))
+	t.Cleanup(srv.Close)
+
+	d = NewFakeDialer(srv.URL, secret)
+	d.RingDelay = 10 * time.Millisecond
+	d.AnswerDelay = 10 * time.Millisecond
+	d.TalkTime = 10 * time.Millisecond
+	return d, events
+}
+
+// collect reads events until the hangup
+func collect(t *testing.T, events <-chan Event) []Event {
+	t.Helper()
+	var got []Event
+	for {
+		select {
+		case e := <-events:
+			got = append(got, e)
+			if e.Type == EventHangup {
+				return got
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no hangup after events %+v", got)
+		}
+	}
+}
+
+func TestFakeDialerOutcomes(t *testing.T) {
+	tests := []struct {
+		to    string
+		types []string
+		cause string
+	}{
+		{"+12175551235", []string{EventRinging, EventAnswered, EventHangup}, CauseCompleted},
+		{"+12175551230", []string{EventRinging, EventHangup}, CauseNoAnswer},
+		{"+12175551231", []string{EventHangup}, CauseBusy},
+		{"+12175551238", []string{EventHangup}, CauseFailed},
+		{"+12175551239", []string{EventHangup}, CauseInvalidNumber},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.to, func(t *testing.T) {
+			d, events := recordEvents(t, "secret")
+			if err := d.Dial(context.Background(), Call{ID: "call-1", To: tt.to}); err != nil {
+				t.Fatal(err)
+			}
+			got := collect(t, events)
+			if len(got) != len(tt.types) {
+				t.Fatalf("got %d events %+v, want types %v", len(got), got, tt.types)
+			}
+			for i, e := range got {
+				if e.Type != tt.types[i] || e.CallID != "call-1" {
+					t.Errorf("event %d = %s for %s, want %s for call-1", i, e.Type, e.CallID, tt.types[i])
+				}
+			}
+			if hangup := got[len(got)-1]; hangup.Cause != tt.cause {
+				t.Errorf("hangup cause = %q, want %q", hangup.Cause, tt.cause)
+			}
+		})
+	}
+}
+
+func TestFakeDialerTalkTime(t *testing.T) {
+	d, events := recordEvents(t, "secret")
+	d.TalkTime = 1100 * time.Millisecond
+	if err := d.Dial(context.Background(), Call{ID: "call-1", To: "+12175551235"}); err != nil {
+		t.Fatal(err)
+	}
+	got := collect(t, events)
+	if hangup := got[len(got)-1]; hangup.Duration != 1 {
+		t.Errorf("hangup duration = %d, want 1", hangup.Duration)
+	}
+}
+
+func TestFakeDialerAgentHangup(t *testing.T) {
+	d, events := recordEvents(t, "secret")
+	d.RingDelay = time.Second
+	if err := d.Dial(context.Background(), Call{ID: "call-1", To: "+12175551235"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Hangup(context.Background(), "call-1"); err != nil {
+		t.Fatalf("Hangup: %v", err)
+	}
+	got := collect(t, events)
+	if len(got) != 1 || got[0].Cause != CauseCanceled {
+		t.Errorf("events = %+v, want one hangup canceled", got)
+	}
+	if err := d.Hangup(context.Background(), "call-1"); err != ErrUnknownCall {
+		t.Errorf("second Hangup error = %v, want ErrUnknownCall", err)
+	}
+}
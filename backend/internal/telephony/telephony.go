@@ -0,0 +1,167 @@
+// Package telephony places outbound calls for agents and turns carrier
+// webhooks into call events. Each carrier is a Dialer; the fake dialer
+// simulates calls locally so no carrier is needed in development.
+package telephony
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Event types, in the order a call normally moves through them
+const (
+	EventRinging  = "ringing"
+	EventAnswered = "answered"
+	EventHangup   = "hangup"
+)
+
+// Hangup causes reported with EventHangup
+const (
+	CauseCompleted     = "completed"      // answered call ended normally
+	CauseNoAnswer      = "no_answer"      // rang out without being picked up
+	CauseBusy          = "busy"           // line busy
+	CauseFailed        = "failed"         // carrier could not connect the call
+	CauseInvalidNumber = "invalid_number" // number not in service
+	CauseCanceled      = "canceled"       // agent hung up before it was answered
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the webhook body
+const SignatureHeader = "X-Telephony-Signature"
+
+// maxEventSize bounds webhook bodies
+const maxEventSize = 64 << 10
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidEvent     = errors.New("invalid call event")
+	ErrUnknownCall      = errors.New("unknown call")
+)
+
+// Call is an outbound call the dialer should place
+type Call struct {
+	ID string // our call ID; carriers must echo it back in events
+	To string // number to dial
+}
+
+// Event is one step in a call's life as reported by the carrier
+type Event struct {
+	CallID    string    `json:"call_id"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+	Duration  int       `json:"duration,omitempty"` // talk time in seconds, on hangup
+	Cause     string    `json:"cause,omitempty"`    // hangup cause
+}
+
+// Dialer is a carrier integration
+type Dialer interface {
+	// Name identifies the carrier in call records
+	Name() string
+	// Dial starts an outbound call; its progress arrives later as events
+	Dial(ctx context.Context, call Call) error
+	// Hangup ends a call the agent no longer wants
+	Hangup(ctx context.Context, callID string) error
+	// ParseEvent authenticates and decodes a webhook request
+	ParseEvent(r *http.Request) (*Event, error)
+}
+
+// Config selects and configures the dialer
+type Config struct {
+	Provider      string // none (the default) disables click-to-dial; fake is for development
+	WebhookURL    string // where the carrier posts call events
+	WebhookSecret string // shared secret for webhook signatures
+}
+
+// LoadConfig reads telephony settings from the environment. Dialing stays
+// off unless a provider is named, so a deployment never records the fake
+// dialer's simulated outcomes by accident.
+func LoadConfig() *Config {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	return &Config{
+		Provider:      getEnv("TELEPHONY_PROVIDER", "none"),
+		WebhookURL:    getEnv("TELEPHONY_WEBHOOK_URL", "http://localhost:"+port+"/api/telephony/events"),
+		WebhookSecret: os.Getenv("TELEPHONY_WEBHOOK_SECRET"),
+	}
+}
+
+// New builds the configured dialer; it returns nil when telephony is disabled
+func New(config *Config) (Dialer, error) {
+	switch config.Provider {
+	case "", "none":
+		return nil, nil
+	case "fake":
+		secret := config.WebhookSecret
+		if secret == "" {
+			// The fake dialer signs and verifies its own events
+			secret = randomSecret()
+		}
+		return NewFakeDialer(config.WebhookURL, secret), nil
+	default:
+		return nil, fmt.Errorf("unknown telephony provider %q", config.Provider)
+	}
+}
+
+// Sign returns the signature carriers send in SignatureHeader
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ParseSignedEvent verifies a body signed with Sign and decodes the event
+func ParseSignedEvent(r *http.Request, secret string) (*Event, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxEventSize))
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(Sign(secret, body)), []byte(r.Header.Get(SignatureHeader))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, ErrInvalidEvent
+	}
+	if event.CallID == "" {
+		return nil, ErrInvalidEvent
+	}
+	switch event.Type {
+	case EventRinging, EventAnswered, EventHangup:
+	default:
+		return nil, ErrInvalidEvent
+	}
+	if event.Duration < 0 {
+		return nil, ErrInvalidEvent
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	return &event, nil
+}
+
+func randomSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,45 @@
+package telephony
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseSignedEvent(t *testing.T) {
+	const secret = "webhook-secret"
+	body := `{"call_id":"c1","type":"hangup","cause":"busy"}`
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		err       error
+	}{
+		{"valid", body, Sign(secret, []byte(body)), nil},
+		{"wrong secret", body, Sign("other-secret", []byte(body)), ErrInvalidSignature},
+		{"tampered body", `{"call_id":"c1","type":"hangup","cause":"no_answer"}`, Sign(secret, []byte(body)), ErrInvalidSignature},
+		{"missing signature", body, "", ErrInvalidSignature},
+		{"unknown type", `{"call_id":"c1","type":"transferred"}`, Sign(secret, []byte(`{"call_id":"c1","type":"transferred"}`)), ErrInvalidEvent},
+		{"missing call ID", `{"type":"ringing"}`, Sign(secret, []byte(`{"type":"ringing"}`)), ErrInvalidEvent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/telephony/events", bytes.NewBufferString(tt.body))
+			if tt.signature != "" {
+				r.Header.Set(SignatureHeader, tt.signature)
+			}
+			event, err := ParseSignedEvent(r, secret)
+			if err != tt.err {
+				t.Fatalf("ParseSignedEvent() error = %v, want %v", err, tt.err)
+			}
+			if err == nil && (event.CallID != "c1" || event.Type != EventHangup || event.Cause != CauseBusy) {
+				t.Errorf("ParseSignedEvent() = %+v", event)
+			}
+			if err == nil && event.Timestamp.IsZero() {
+				t.Error("event without a timestamp was not stamped")
+			}
+		})
+	}
+}
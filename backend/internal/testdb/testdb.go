@@ -0,0 +1,80 @@
+// Package testdb gives integration tests a throwaway PostgreSQL database with
+// every migration applied. Tests are skipped unless TEST_DATABASE_URL names a
+// server (in URL form) where the user may create databases; the database the
+// URL points at is only used to create and drop the test databases.
+package testdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/user/auth-app/internal/database"
+)
+
+// Open creates a fresh database, migrates it and points database.DB at it for
+// the rest of the test. Everything is dropped when the test ends.
+func Open(t testing.TB) *pgxpool.Pool {
+	t.Helper()
+
+	serverURL := os.Getenv("TEST_DATABASE_URL")
+	if serverURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	admin, err := pgx.Connect(ctx, serverURL)
+	if err != nil {
+		t.Fatalf("connect to test server: %v", err)
+	}
+	t.Cleanup(func() { admin.Close(context.Background()) })
+
+	name := fmt.Sprintf("bpo_test_%d_%d", os.Getpid(), time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, "CREATE DATABASE "+pgx.Identifier{name}.Sanitize()); err != nil {
+		t.Fatalf("create test database: %v", err)
+	}
+	t.Cleanup(func() {
+		_, err := admin.Exec(context.Background(), "DROP DATABASE IF EXISTS "+pgx.Identifier{name}.Sanitize()+" WITH (FORCE)")
+		if err != nil {
+			t.Errorf("drop test database %s: %v", name, err)
+		}
+	})
+
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL must be a URL: %v", err)
+	}
+	u.Path = "/" + name
+	databaseURL := u.String()
+
+	if err := database.RunMigrations(databaseURL, migrationsPath()); err != nil {
+		t.Fatalf("migrate test database: %v", err)
+	}
+
+	pool, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+	previous := database.DB
+	database.DB = pool
+	t.Cleanup(func() {
+		database.DB = previous
+		pool.Close()
+	})
+
+	return pool
+}
+
+// migrationsPath locates migrations/postgres relative to this file, so tests
+// in any package find it
+func migrationsPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "migrations", "postgres")
+}
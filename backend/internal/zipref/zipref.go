@@ -0,0 +1,247 @@
+// Package zipref is an offline US ZIP code reference used to catch addresses
+// whose ZIP, state and city disagree. The state of every ZIP3 prefix and the
+// state, primary city and county of every 5-digit ZIP are embedded; a file
+// named by ZIP_REFERENCE_FILE can add or override 5-digit ZIPs at startup.
+// Time zones are not kept here: location_timezone in the database is the one
+// source for them.
+package zipref
+
+//go:generate go run ../../cmd/zipref -out zip5.csv
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/user/auth-app/internal/address"
+)
+
+// Precision of an Entry: the 5-digit ZIP itself or only its ZIP3 prefix
+const (
+	PrecisionZip5 = "zip5"
+	PrecisionZip3 = "zip3"
+)
+
+//go:embed zips.csv
+var embeddedRanges []byte
+
+//go:embed zip5.csv
+var embeddedZip5 []byte
+
+// Entry is what the reference knows about a ZIP code
+type Entry struct {
+	Zip       string `json:"zip"`
+	State     string `json:"state"`
+	City      string `json:"city,omitempty"`
+	County    string `json:"county,omitempty"`
+	Precision string `json:"precision"`
+}
+
+// Issue is one disagreement between an address and the reference
+type Issue struct {
+	Field    string `json:"field"` // zip, state or city
+	Expected string `json:"expected,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Blocking reports whether the issue means the address is wrong. Cities have
+// too many accepted aliases to be more than a warning.
+func (i Issue) Blocking() bool {
+	return i.Field != "city"
+}
+
+var (
+	mu   sync.RWMutex
+	zip3 = map[string]Entry{}
+	zip5 = map[string]Entry{}
+)
+
+func init() {
+	if err := loadRanges(bytes.NewReader(embeddedRanges)); err != nil {
+		panic("zipref: embedded ZIP3 reference: " + err.Error())
+	}
+	if err := loadZip5(bytes.NewReader(embeddedZip5)); err != nil {
+		panic("zipref: embedded 5-digit reference: " + err.Error())
+	}
+}
+
+// loadRanges reads the embedded "from,to,state" rows, where from
+// and to are both ZIP3 prefixes or both 5-digit ZIPs
+func loadRanges(r io.Reader) error {
+	rows, err := readCSV(r)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, row := range rows[1:] {
+		if len(row) < 3 || len(row[0]) != len(row[1]) {
+			return fmt.Errorf("invalid range row %v", row)
+		}
+		from, err := strconv.Atoi(row[0])
+		if err != nil {
+			return fmt.Errorf("invalid range row %v", row)
+		}
+		to, err := strconv.Atoi(row[1])
+		if err != nil {
+			return fmt.Errorf("invalid range row %v", row)
+		}
+		width := len(row[0])
+		for n := from; n <= to; n++ {
+			zip := fmt.Sprintf("%0*d", width, n)
+			entry := Entry{Zip: zip, State: row[2]}
+			if width == 3 {
+				entry.Precision = PrecisionZip3
+				zip3[zip] = entry
+			} else {
+				entry.Precision = PrecisionZip5
+				zip5[zip] = entry
+			}
+		}
+	}
+	return nil
+}
+
+// LoadFromEnv merges the file named by ZIP_REFERENCE_FILE, when set
+func LoadFromEnv() error {
+	path := os.Getenv("ZIP_REFERENCE_FILE")
+	if path == "" {
+		return nil
+	}
+	return LoadFile(path)
+}
+
+// LoadFile merges a CSV of 5-digit ZIPs into the reference, replacing the
+// embedded rows for the same ZIPs
+func LoadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := loadZip5(file); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+// loadZip5 reads 5-digit ZIP rows. The header names the columns: zip is
+// required; state, city and county are optional, and a missing state falls
+// back to the ZIP3's.
+func loadZip5(r io.Reader) error {
+	rows, err := readCSV(r)
+	if err != nil {
+		return err
+	}
+
+	columns := map[string]int{}
+	for i, name := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["zip"]; !ok {
+		return fmt.Errorf("header has no zip column")
+	}
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for line, row := range rows[1:] {
+		zip := field(row, "zip")
+		if len(zip) == 4 {
+			zip = "0" + zip
+		}
+		if len(zip) != 5 {
+			return fmt.Errorf("line %d: invalid zip %q", line+2, zip)
+		}
+		entry := Entry{
+			Zip:       zip,
+			State:     strings.ToUpper(field(row, "state")),
+			City:      address.City(field(row, "city")),
+			County:    strings.ToUpper(field(row, "county")),
+			Precision: PrecisionZip5,
+		}
+		if entry.State == "" {
+			entry.State = zip3[zip[:3]].State
+		}
+		zip5[zip] = entry
+	}
+	return nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("missing header")
+	}
+	return rows, nil
+}
+
+// Lookup returns the reference entry for a ZIP or ZIP+4, from the 5-digit
+// data when it has the ZIP and from the ZIP3 prefix otherwise
+func Lookup(zip string) (Entry, bool) {
+	zip, err := address.Zip(zip)
+	if err != nil {
+		return Entry{}, false
+	}
+	zip = zip[:5]
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if entry, ok := zip5[zip]; ok {
+		return entry, true
+	}
+	entry, ok := zip3[zip[:3]]
+	if !ok {
+		return Entry{}, false
+	}
+	entry.Zip = zip
+	return entry, true
+}
+
+// Check compares an address's state and city with the reference for its
+// ZIP. Empty fields and malformed ZIPs are not checked.
+func Check(state, city, zip string) []Issue {
+	if _, err := address.Zip(zip); err != nil {
+		return nil
+	}
+	entry, ok := Lookup(zip)
+	if !ok {
+		return []Issue{{Field: "zip", Message: fmt.Sprintf("ZIP %s is not a known US ZIP code", zip)}}
+	}
+
+	var issues []Issue
+	if state = strings.ToUpper(strings.TrimSpace(state)); state != "" && state != entry.State {
+		issues = append(issues, Issue{
+			Field:    "state",
+			Expected: entry.State,
+			Message:  fmt.Sprintf("ZIP %s is in %s, not %s", entry.Zip, entry.State, state),
+		})
+	}
+	if city = address.City(city); city != "" && entry.City != "" && city != entry.City {
+		issues = append(issues, Issue{
+			Field:    "city",
+			Expected: entry.City,
+			Message:  fmt.Sprintf("ZIP %s is %s, not %s", entry.Zip, entry.City, city),
+		})
+	}
+	return issues
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_validation_sessions_in_progress_lock;
@@ -0,0 +1,4 @@
+-- Support the session reaper's scan for abandoned in-progress sessions
+CREATE INDEX IF NOT EXISTS idx_validation_sessions_in_progress_lock
+    ON validation_sessions(locked_at, updated_at)
+    WHERE status = 'in_progress';
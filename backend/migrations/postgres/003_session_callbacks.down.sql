@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_validation_sessions_callbacks;
+ALTER TABLE validation_sessions DROP COLUMN IF EXISTS callback_at;
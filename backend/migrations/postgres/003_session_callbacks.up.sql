@@ -0,0 +1,6 @@
+-- Scheduled callback time for on-hold validation sessions
+ALTER TABLE validation_sessions ADD COLUMN IF NOT EXISTS callback_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_validation_sessions_callbacks
+    ON validation_sessions(callback_at, user_id)
+    WHERE status = 'on_hold';
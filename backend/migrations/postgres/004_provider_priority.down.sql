@@ -0,0 +1,9 @@
+DROP FUNCTION IF EXISTS provider_effective_priority(INTEGER, TIMESTAMPTZ, INTEGER);
+
+DROP INDEX IF EXISTS idx_providers_queue_order;
+ALTER TABLE providers DROP CONSTRAINT IF EXISTS valid_priority;
+ALTER TABLE providers DROP COLUMN IF EXISTS due_date;
+ALTER TABLE providers DROP COLUMN IF EXISTS priority;
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS valid_role;
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,25 @@
+-- User roles for supervisor-only operations
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'agent';
+ALTER TABLE users ADD CONSTRAINT valid_role CHECK (role IN ('agent', 'supervisor', 'admin'));
+
+-- Work prioritization: 1 (lowest) to 10 (most urgent)
+ALTER TABLE providers ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 5;
+ALTER TABLE providers ADD COLUMN IF NOT EXISTS due_date TIMESTAMPTZ;
+ALTER TABLE providers ADD CONSTRAINT valid_priority CHECK (priority >= 1 AND priority <= 10);
+
+CREATE INDEX IF NOT EXISTS idx_providers_queue_order
+    ON providers(priority DESC, due_date ASC NULLS LAST, created_at, id)
+    WHERE is_active = true;
+
+-- Priority after aging: one step per aging interval spent waiting, capped at 10
+CREATE OR REPLACE FUNCTION provider_effective_priority(
+    base_priority INTEGER,
+    queued_at TIMESTAMPTZ,
+    aging_seconds INTEGER
+)
+RETURNS INTEGER AS $$
+    SELECT LEAST(10, base_priority + CASE
+        WHEN aging_seconds IS NULL OR aging_seconds <= 0 THEN 0
+        ELSE FLOOR(EXTRACT(EPOCH FROM (CURRENT_TIMESTAMP - queued_at)) / aging_seconds)::INTEGER
+    END)
+$$ LANGUAGE sql STABLE;
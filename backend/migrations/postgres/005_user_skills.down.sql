@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_providers_specialty_lower;
+DROP TABLE IF EXISTS user_skills;
@@ -0,0 +1,15 @@
+-- Agent skills used to route providers: licensed states, languages, specialties
+CREATE TABLE IF NOT EXISTS user_skills (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    skill_type VARCHAR(20) NOT NULL,
+    skill_value VARCHAR(200) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER REFERENCES users(id),
+
+    CONSTRAINT valid_skill_type CHECK (skill_type IN ('state', 'language', 'specialty')),
+    UNIQUE (user_id, skill_type, skill_value)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_skills_user_type ON user_skills(user_id, skill_type);
+CREATE INDEX IF NOT EXISTS idx_providers_specialty_lower ON providers(LOWER(specialty));
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS sync_work_queue_providers ON providers;
+DROP TRIGGER IF EXISTS sync_work_queue_sessions ON validation_sessions;
+DROP TRIGGER IF EXISTS sync_work_queue_phones ON provider_phones;
+DROP TRIGGER IF EXISTS sync_work_queue_addresses ON provider_addresses;
+
+DROP FUNCTION IF EXISTS sync_work_queue_for_provider();
+DROP FUNCTION IF EXISTS sync_work_queue_for_provider_row();
+DROP FUNCTION IF EXISTS refresh_work_queue(INTEGER);
+
+DROP TABLE IF EXISTS work_queue;
@@ -0,0 +1,111 @@
+-- Claimable providers, maintained by triggers so GetNextProvider claims a
+-- single indexed row instead of scanning providers, addresses and phones
+CREATE TABLE IF NOT EXISTS work_queue (
+    provider_id INTEGER PRIMARY KEY REFERENCES providers(id) ON DELETE CASCADE,
+    base_priority INTEGER NOT NULL DEFAULT 5,
+    priority INTEGER NOT NULL DEFAULT 5,
+    due_date TIMESTAMPTZ,
+    queued_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Denormalized routing attributes for skill matching
+    states TEXT[] NOT NULL DEFAULT '{}',
+    specialty TEXT,
+    languages TEXT[] NOT NULL DEFAULT '{}',
+
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_work_queue_claim_order
+    ON work_queue(priority DESC, due_date ASC NULLS LAST, queued_at, provider_id);
+CREATE INDEX IF NOT EXISTS idx_work_queue_states ON work_queue USING gin(states);
+CREATE INDEX IF NOT EXISTS idx_work_queue_languages ON work_queue USING gin(languages);
+
+CREATE TRIGGER update_work_queue_updated_at BEFORE UPDATE ON work_queue
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Add, update or remove a provider's queue entry based on its current state
+CREATE OR REPLACE FUNCTION refresh_work_queue(target_provider_id INTEGER)
+RETURNS void AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM providers p
+        WHERE p.id = target_provider_id
+          AND p.is_active = true
+          AND EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id)
+          AND EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id)
+          AND (EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id AND pa.is_correct IS NULL)
+               OR EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id AND pp.is_correct IS NULL))
+          AND NOT EXISTS (
+              SELECT 1 FROM validation_sessions vs
+              WHERE vs.provider_id = p.id AND vs.status IN ('in_progress', 'on_hold')
+          )
+    ) THEN
+        INSERT INTO work_queue (provider_id, base_priority, priority, due_date, queued_at,
+                                states, specialty, languages)
+        SELECT p.id, p.priority, p.priority, p.due_date, p.created_at,
+               ARRAY(SELECT DISTINCT pa.state FROM provider_addresses pa
+                     WHERE pa.provider_id = p.id AND pa.state IS NOT NULL),
+               LOWER(p.specialty),
+               ARRAY(SELECT LOWER(lang) FROM jsonb_array_elements_text(
+                     CASE WHEN jsonb_typeof(p.metadata->'languages') = 'array'
+                          THEN p.metadata->'languages' ELSE '[]'::jsonb END) AS lang)
+        FROM providers p
+        WHERE p.id = target_provider_id
+        ON CONFLICT (provider_id) DO UPDATE SET
+            -- keep any aging bonus already earned
+            priority = LEAST(10, EXCLUDED.base_priority + (work_queue.priority - work_queue.base_priority)),
+            base_priority = EXCLUDED.base_priority,
+            due_date = EXCLUDED.due_date,
+            states = EXCLUDED.states,
+            specialty = EXCLUDED.specialty,
+            languages = EXCLUDED.languages;
+    ELSE
+        DELETE FROM work_queue WHERE provider_id = target_provider_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Keep the queue in sync with addresses, phones and sessions
+CREATE OR REPLACE FUNCTION sync_work_queue_for_provider_row()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM refresh_work_queue(OLD.provider_id);
+        RETURN OLD;
+    END IF;
+
+    IF TG_OP = 'UPDATE' AND OLD.provider_id <> NEW.provider_id THEN
+        PERFORM refresh_work_queue(OLD.provider_id);
+    END IF;
+    PERFORM refresh_work_queue(NEW.provider_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Keep the queue in sync with provider activation, priority and routing fields
+CREATE OR REPLACE FUNCTION sync_work_queue_for_provider()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM refresh_work_queue(NEW.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER sync_work_queue_addresses
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, is_correct, state ON provider_addresses
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
+
+CREATE TRIGGER sync_work_queue_phones
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, is_correct ON provider_phones
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
+
+CREATE TRIGGER sync_work_queue_sessions
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, status ON validation_sessions
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
+
+CREATE TRIGGER sync_work_queue_providers
+    AFTER UPDATE OF is_active, priority, due_date, specialty, metadata ON providers
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider();
+
+-- Backfill from existing data
+SELECT refresh_work_queue(id) FROM providers;
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS maintain_provider_claim_trigger ON validation_sessions;
+DROP FUNCTION IF EXISTS maintain_provider_claim();
+DROP TABLE IF EXISTS provider_claims;
@@ -0,0 +1,69 @@
+-- One row per provider with an active (in_progress or on_hold) session. The
+-- primary key guarantees a provider is never assigned to two sessions at once;
+-- validation_sessions is partitioned, so a partial unique index there would
+-- have to include created_at and could not enforce this.
+CREATE TABLE IF NOT EXISTS provider_claims (
+    provider_id INTEGER PRIMARY KEY REFERENCES providers(id) ON DELETE CASCADE,
+    session_id INTEGER NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    claimed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_provider_claims_user_id ON provider_claims(user_id);
+
+-- Cancel all but the oldest active session of any provider that already has duplicates
+UPDATE validation_sessions vs
+SET status = 'cancelled',
+    locked_by = NULL,
+    validation_results = vs.validation_results || jsonb_build_object(
+        'cancelled_at', CURRENT_TIMESTAMP,
+        'cancel_reason', 'duplicate_claim'
+    )
+WHERE vs.status IN ('in_progress', 'on_hold')
+  AND EXISTS (
+      SELECT 1 FROM validation_sessions older
+      WHERE older.provider_id = vs.provider_id
+        AND older.status IN ('in_progress', 'on_hold')
+        AND (older.created_at, older.id) < (vs.created_at, vs.id)
+  );
+
+INSERT INTO provider_claims (provider_id, session_id, user_id)
+SELECT provider_id, id, user_id
+FROM validation_sessions
+WHERE status IN ('in_progress', 'on_hold');
+
+-- Maintain claims as sessions move through their lifecycle
+CREATE OR REPLACE FUNCTION maintain_provider_claim()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE')
+       AND OLD.status IN ('in_progress', 'on_hold')
+       AND (TG_OP = 'DELETE'
+            OR NEW.status NOT IN ('in_progress', 'on_hold')
+            OR NEW.provider_id <> OLD.provider_id) THEN
+        DELETE FROM provider_claims WHERE session_id = OLD.id;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+
+    IF NEW.status IN ('in_progress', 'on_hold') THEN
+        IF TG_OP = 'INSERT'
+           OR OLD.status NOT IN ('in_progress', 'on_hold')
+           OR OLD.provider_id <> NEW.provider_id THEN
+            -- Raises unique_violation if the provider is already claimed
+            INSERT INTO provider_claims (provider_id, session_id, user_id)
+            VALUES (NEW.provider_id, NEW.id, NEW.user_id);
+        ELSIF OLD.user_id <> NEW.user_id THEN
+            UPDATE provider_claims SET user_id = NEW.user_id WHERE session_id = NEW.id;
+        END IF;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER maintain_provider_claim_trigger
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, user_id, status ON validation_sessions
+    FOR EACH ROW EXECUTE FUNCTION maintain_provider_claim();
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_providers_campaign;
+DROP TABLE IF EXISTS call_attempt_policies;
@@ -0,0 +1,24 @@
+-- Call attempt rules per campaign. Providers name their campaign in
+-- metadata.campaign; everyone else follows the 'default' policy.
+CREATE TABLE IF NOT EXISTS call_attempt_policies (
+    campaign VARCHAR(100) PRIMARY KEY,
+    max_attempts INTEGER NOT NULL,
+    -- steps[i] governs attempt i+1: spacing from the previous attempt and allowed outcomes
+    steps JSONB NOT NULL DEFAULT '[]'::jsonb,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_by INTEGER REFERENCES users(id),
+
+    CONSTRAINT valid_max_attempts CHECK (max_attempts BETWEEN 1 AND 10),
+    CONSTRAINT valid_steps CHECK (jsonb_typeof(steps) = 'array')
+);
+
+CREATE TRIGGER update_call_attempt_policies_updated_at BEFORE UPDATE ON call_attempt_policies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- The historical rule: two attempts, the second at least one business day after the first
+INSERT INTO call_attempt_policies (campaign, max_attempts, steps)
+VALUES ('default', 2, '[{}, {"min_business_days": 1}]'::jsonb)
+ON CONFLICT (campaign) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_providers_campaign ON providers((metadata->>'campaign'));
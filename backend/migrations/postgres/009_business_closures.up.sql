@@ -0,0 +1,15 @@
+-- Days provider offices are closed beyond weekends and US federal holidays
+-- (which are computed in code). A NULL state closes every state.
+CREATE TABLE IF NOT EXISTS business_closures (
+    id SERIAL PRIMARY KEY,
+    closure_date DATE NOT NULL,
+    state CHAR(2),
+    name VARCHAR(200) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    created_by INTEGER REFERENCES users(id),
+
+    CONSTRAINT valid_closure_state CHECK (state IS NULL OR state ~ '^[A-Z]{2}$')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_business_closures_date_state
+    ON business_closures(closure_date, COALESCE(state, ''));
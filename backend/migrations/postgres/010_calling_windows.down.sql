@@ -0,0 +1,55 @@
+DROP TRIGGER IF EXISTS sync_work_queue_addresses ON provider_addresses;
+CREATE TRIGGER sync_work_queue_addresses
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, is_correct, state ON provider_addresses
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
+
+-- Add, update or remove a provider's queue entry based on its current state
+CREATE OR REPLACE FUNCTION refresh_work_queue(target_provider_id INTEGER)
+RETURNS void AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM providers p
+        WHERE p.id = target_provider_id
+          AND p.is_active = true
+          AND EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id)
+          AND EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id)
+          AND (EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id AND pa.is_correct IS NULL)
+               OR EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id AND pp.is_correct IS NULL))
+          AND NOT EXISTS (
+              SELECT 1 FROM validation_sessions vs
+              WHERE vs.provider_id = p.id AND vs.status IN ('in_progress', 'on_hold')
+          )
+    ) THEN
+        INSERT INTO work_queue (provider_id, base_priority, priority, due_date, queued_at,
+                                states, specialty, languages)
+        SELECT p.id, p.priority, p.priority, p.due_date, p.created_at,
+               ARRAY(SELECT DISTINCT pa.state FROM provider_addresses pa
+                     WHERE pa.provider_id = p.id AND pa.state IS NOT NULL),
+               LOWER(p.specialty),
+               ARRAY(SELECT LOWER(lang) FROM jsonb_array_elements_text(
+                     CASE WHEN jsonb_typeof(p.metadata->'languages') = 'array'
+                          THEN p.metadata->'languages' ELSE '[]'::jsonb END) AS lang)
+        FROM providers p
+        WHERE p.id = target_provider_id
+        ON CONFLICT (provider_id) DO UPDATE SET
+            -- keep any aging bonus already earned
+            priority = LEAST(10, EXCLUDED.base_priority + (work_queue.priority - work_queue.base_priority)),
+            base_priority = EXCLUDED.base_priority,
+            due_date = EXCLUDED.due_date,
+            states = EXCLUDED.states,
+            specialty = EXCLUDED.specialty,
+            languages = EXCLUDED.languages;
+    ELSE
+        DELETE FROM work_queue WHERE provider_id = target_provider_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE work_queue DROP COLUMN IF EXISTS timezone;
+
+DROP FUNCTION IF EXISTS within_calling_window(TEXT, TIME, TIME);
+DROP FUNCTION IF EXISTS provider_timezone(INTEGER);
+DROP FUNCTION IF EXISTS location_timezone(TEXT, TEXT);
+
+DROP TABLE IF EXISTS zip3_timezones;
+DROP TABLE IF EXISTS state_timezones;
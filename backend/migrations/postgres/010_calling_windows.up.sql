@@ -0,0 +1,189 @@
+-- Time zones used to keep calls inside provider office hours. Each state maps
+-- to its predominant zone; ZIP3 overrides cover the main split-zone areas.
+CREATE TABLE IF NOT EXISTS state_timezones (
+    state CHAR(2) PRIMARY KEY,
+    timezone TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS zip3_timezones (
+    zip3 CHAR(3) PRIMARY KEY,
+    timezone TEXT NOT NULL
+);
+
+INSERT INTO state_timezones (state, timezone) VALUES
+    ('AL', 'America/Chicago'),
+    ('AK', 'America/Anchorage'),
+    ('AZ', 'America/Phoenix'),
+    ('AR', 'America/Chicago'),
+    ('CA', 'America/Los_Angeles'),
+    ('CO', 'America/Denver'),
+    ('CT', 'America/New_York'),
+    ('DE', 'America/New_York'),
+    ('DC', 'America/New_York'),
+    ('FL', 'America/New_York'),
+    ('GA', 'America/New_York'),
+    ('HI', 'Pacific/Honolulu'),
+    ('ID', 'America/Boise'),
+    ('IL', 'America/Chicago'),
+    ('IN', 'America/Indiana/Indianapolis'),
+    ('IA', 'America/Chicago'),
+    ('KS', 'America/Chicago'),
+    ('KY', 'America/New_York'),
+    ('LA', 'America/Chicago'),
+    ('ME', 'America/New_York'),
+    ('MD', 'America/New_York'),
+    ('MA', 'America/New_York'),
+    ('MI', 'America/Detroit'),
+    ('MN', 'America/Chicago'),
+    ('MS', 'America/Chicago'),
+    ('MO', 'America/Chicago'),
+    ('MT', 'America/Denver'),
+    ('NE', 'America/Chicago'),
+    ('NV', 'America/Los_Angeles'),
+    ('NH', 'America/New_York'),
+    ('NJ', 'America/New_York'),
+    ('NM', 'America/Denver'),
+    ('NY', 'America/New_York'),
+    ('NC', 'America/New_York'),
+    ('ND', 'America/Chicago'),
+    ('OH', 'America/New_York'),
+    ('OK', 'America/Chicago'),
+    ('OR', 'America/Los_Angeles'),
+    ('PA', 'America/New_York'),
+    ('RI', 'America/New_York'),
+    ('SC', 'America/New_York'),
+    ('SD', 'America/Chicago'),
+    ('TN', 'America/Chicago'),
+    ('TX', 'America/Chicago'),
+    ('UT', 'America/Denver'),
+    ('VT', 'America/New_York'),
+    ('VA', 'America/New_York'),
+    ('WA', 'America/Los_Angeles'),
+    ('WV', 'America/New_York'),
+    ('WI', 'America/Chicago'),
+    ('WY', 'America/Denver'),
+    ('PR', 'America/Puerto_Rico'),
+    ('GU', 'Pacific/Guam'),
+    ('VI', 'America/St_Thomas'),
+    ('AS', 'Pacific/Pago_Pago'),
+    ('MP', 'Pacific/Saipan')
+ON CONFLICT (state) DO NOTHING;
+
+-- Florida panhandle, El Paso, East Tennessee, Western Kentucky, NW/SW Indiana,
+-- western Dakotas and Nebraska, eastern Oregon, northern Idaho
+INSERT INTO zip3_timezones (zip3, timezone) VALUES
+    ('324', 'America/Chicago'),
+    ('325', 'America/Chicago'),
+    ('798', 'America/Denver'),
+    ('799', 'America/Denver'),
+    ('885', 'America/Denver'),
+    ('373', 'America/New_York'),
+    ('374', 'America/New_York'),
+    ('376', 'America/New_York'),
+    ('377', 'America/New_York'),
+    ('378', 'America/New_York'),
+    ('379', 'America/New_York'),
+    ('420', 'America/Chicago'),
+    ('421', 'America/Chicago'),
+    ('422', 'America/Chicago'),
+    ('423', 'America/Chicago'),
+    ('424', 'America/Chicago'),
+    ('463', 'America/Chicago'),
+    ('464', 'America/Chicago'),
+    ('476', 'America/Chicago'),
+    ('477', 'America/Chicago'),
+    ('577', 'America/Denver'),
+    ('586', 'America/Denver'),
+    ('693', 'America/Denver'),
+    ('979', 'America/Boise'),
+    ('835', 'America/Los_Angeles'),
+    ('838', 'America/Los_Angeles')
+ON CONFLICT (zip3) DO NOTHING;
+
+-- Time zone for a state/ZIP pair, or NULL when the state is unknown
+CREATE OR REPLACE FUNCTION location_timezone(loc_state TEXT, loc_zip TEXT)
+RETURNS TEXT AS $$
+    SELECT COALESCE(
+        (SELECT timezone FROM zip3_timezones WHERE zip3 = LEFT(loc_zip, 3)),
+        (SELECT timezone FROM state_timezones WHERE state = UPPER(loc_state))
+    );
+$$ LANGUAGE sql STABLE;
+
+-- Time zone of the provider's main office: practice addresses first,
+-- preferring corrected values and skipping addresses marked incorrect
+CREATE OR REPLACE FUNCTION provider_timezone(target_provider_id INTEGER)
+RETURNS TEXT AS $$
+    SELECT location_timezone(COALESCE(pa.corrected_state, pa.state), COALESCE(pa.corrected_zip, pa.zip))
+    FROM provider_addresses pa
+    WHERE pa.provider_id = target_provider_id
+      AND location_timezone(COALESCE(pa.corrected_state, pa.state), COALESCE(pa.corrected_zip, pa.zip)) IS NOT NULL
+    ORDER BY (pa.address_category = 'practice') DESC,
+             (pa.is_correct IS DISTINCT FROM false) DESC,
+             pa.id
+    LIMIT 1;
+$$ LANGUAGE sql STABLE;
+
+-- Whether it is currently a weekday within [window_start, window_end) in tz.
+-- Providers without a known time zone are always callable.
+CREATE OR REPLACE FUNCTION within_calling_window(tz TEXT, window_start TIME, window_end TIME)
+RETURNS BOOLEAN AS $$
+    SELECT tz IS NULL OR (
+        EXTRACT(ISODOW FROM CURRENT_TIMESTAMP AT TIME ZONE tz) BETWEEN 1 AND 5
+        AND (CURRENT_TIMESTAMP AT TIME ZONE tz)::time >= window_start
+        AND (CURRENT_TIMESTAMP AT TIME ZONE tz)::time < window_end
+    );
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE work_queue ADD COLUMN IF NOT EXISTS timezone TEXT;
+
+-- Add, update or remove a provider's queue entry based on its current state
+CREATE OR REPLACE FUNCTION refresh_work_queue(target_provider_id INTEGER)
+RETURNS void AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM providers p
+        WHERE p.id = target_provider_id
+          AND p.is_active = true
+          AND EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id)
+          AND EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id)
+          AND (EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id AND pa.is_correct IS NULL)
+               OR EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id AND pp.is_correct IS NULL))
+          AND NOT EXISTS (
+              SELECT 1 FROM validation_sessions vs
+              WHERE vs.provider_id = p.id AND vs.status IN ('in_progress', 'on_hold')
+          )
+    ) THEN
+        INSERT INTO work_queue (provider_id, base_priority, priority, due_date, queued_at,
+                                states, specialty, languages, timezone)
+        SELECT p.id, p.priority, p.priority, p.due_date, p.created_at,
+               ARRAY(SELECT DISTINCT pa.state FROM provider_addresses pa
+                     WHERE pa.provider_id = p.id AND pa.state IS NOT NULL),
+               LOWER(p.specialty),
+               ARRAY(SELECT LOWER(lang) FROM jsonb_array_elements_text(
+                     CASE WHEN jsonb_typeof(p.metadata->'languages') = 'array'
+                          THEN p.metadata->'languages' ELSE '[]'::jsonb END) AS lang),
+               provider_timezone(p.id)
+        FROM providers p
+        WHERE p.id = target_provider_id
+        ON CONFLICT (provider_id) DO UPDATE SET
+            -- keep any aging bonus already earned
+            priority = LEAST(10, EXCLUDED.base_priority + (work_queue.priority - work_queue.base_priority)),
+            base_priority = EXCLUDED.base_priority,
+            due_date = EXCLUDED.due_date,
+            states = EXCLUDED.states,
+            specialty = EXCLUDED.specialty,
+            languages = EXCLUDED.languages,
+            timezone = EXCLUDED.timezone;
+    ELSE
+        DELETE FROM work_queue WHERE provider_id = target_provider_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Re-queue when an address moves to another ZIP as well as another state
+DROP TRIGGER IF EXISTS sync_work_queue_addresses ON provider_addresses;
+CREATE TRIGGER sync_work_queue_addresses
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, is_correct, state, zip ON provider_addresses
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
+
+UPDATE work_queue SET timezone = provider_timezone(provider_id);
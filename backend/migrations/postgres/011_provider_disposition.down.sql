@@ -0,0 +1,58 @@
+DROP VIEW IF EXISTS provider_validation_outcomes;
+
+DROP TRIGGER IF EXISTS sync_work_queue_providers ON providers;
+CREATE TRIGGER sync_work_queue_providers
+    AFTER UPDATE OF is_active, priority, due_date, specialty, metadata ON providers
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider();
+
+-- Add, update or remove a provider's queue entry based on its current state
+CREATE OR REPLACE FUNCTION refresh_work_queue(target_provider_id INTEGER)
+RETURNS void AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM providers p
+        WHERE p.id = target_provider_id
+          AND p.is_active = true
+          AND EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id)
+          AND EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id)
+          AND (EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id AND pa.is_correct IS NULL)
+               OR EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id AND pp.is_correct IS NULL))
+          AND NOT EXISTS (
+              SELECT 1 FROM validation_sessions vs
+              WHERE vs.provider_id = p.id AND vs.status IN ('in_progress', 'on_hold')
+          )
+    ) THEN
+        INSERT INTO work_queue (provider_id, base_priority, priority, due_date, queued_at,
+                                states, specialty, languages, timezone)
+        SELECT p.id, p.priority, p.priority, p.due_date, p.created_at,
+               ARRAY(SELECT DISTINCT pa.state FROM provider_addresses pa
+                     WHERE pa.provider_id = p.id AND pa.state IS NOT NULL),
+               LOWER(p.specialty),
+               ARRAY(SELECT LOWER(lang) FROM jsonb_array_elements_text(
+                     CASE WHEN jsonb_typeof(p.metadata->'languages') = 'array'
+                          THEN p.metadata->'languages' ELSE '[]'::jsonb END) AS lang),
+               provider_timezone(p.id)
+        FROM providers p
+        WHERE p.id = target_provider_id
+        ON CONFLICT (provider_id) DO UPDATE SET
+            -- keep any aging bonus already earned
+            priority = LEAST(10, EXCLUDED.base_priority + (work_queue.priority - work_queue.base_priority)),
+            base_priority = EXCLUDED.base_priority,
+            due_date = EXCLUDED.due_date,
+            states = EXCLUDED.states,
+            specialty = EXCLUDED.specialty,
+            languages = EXCLUDED.languages,
+            timezone = EXCLUDED.timezone;
+    ELSE
+        DELETE FROM work_queue WHERE provider_id = target_provider_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_providers_disposition;
+ALTER TABLE providers DROP CONSTRAINT IF EXISTS valid_disposition;
+ALTER TABLE providers DROP COLUMN IF EXISTS disposition_session_id;
+ALTER TABLE providers DROP COLUMN IF EXISTS disposition_by;
+ALTER TABLE providers DROP COLUMN IF EXISTS disposition_at;
+ALTER TABLE providers DROP COLUMN IF EXISTS disposition_reason;
+ALTER TABLE providers DROP COLUMN IF EXISTS disposition;
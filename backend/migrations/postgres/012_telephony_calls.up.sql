@@ -0,0 +1,31 @@
+-- Calls placed through a telephony dialer. Carrier events update the row and
+-- the hangup event records the call attempt on the session. session_id has no
+-- foreign key: validation_sessions is partitioned with a primary key of
+-- (id, created_at), so StartCall checks the session exists instead.
+CREATE TABLE IF NOT EXISTS telephony_calls (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    session_id INTEGER NOT NULL,
+    phone_id INTEGER NOT NULL REFERENCES provider_phones(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    attempt_number INTEGER NOT NULL,
+    dialer VARCHAR(50) NOT NULL,
+    dialed_number VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'dialing',
+    outcome call_attempt_status,
+    hangup_cause VARCHAR(50),
+    duration INTEGER,
+    ringing_at TIMESTAMPTZ,
+    answered_at TIMESTAMPTZ,
+    ended_at TIMESTAMPTZ,
+    record_error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT valid_call_status CHECK (status IN ('dialing', 'ringing', 'answered', 'ended', 'failed')),
+    CONSTRAINT valid_call_duration CHECK (duration IS NULL OR duration >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_telephony_calls_session ON telephony_calls(session_id);
+
+-- At most one live call per session
+CREATE UNIQUE INDEX IF NOT EXISTS idx_telephony_calls_live
+    ON telephony_calls(session_id) WHERE status IN ('dialing', 'ringing', 'answered');
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_flagged_phones_type;
+DROP INDEX IF EXISTS idx_provider_phones_corrected_normalized;
+DROP INDEX IF EXISTS idx_provider_phones_normalized;
+DROP FUNCTION IF EXISTS normalize_phone(TEXT);
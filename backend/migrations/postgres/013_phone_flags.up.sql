@@ -0,0 +1,23 @@
+-- Canonical form for comparing phone numbers: the ten NANP digits, with any
+-- formatting and a leading country code 1 removed. NULL when nothing is left.
+CREATE OR REPLACE FUNCTION normalize_phone(value TEXT)
+RETURNS TEXT AS $$
+    SELECT NULLIF(
+        CASE WHEN length(d) = 11 AND left(d, 1) = '1' THEN substr(d, 2) ELSE d END,
+        '')
+    FROM (SELECT regexp_replace(COALESCE(value, ''), '\D', '', 'g') AS d) digits;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Flags are stored normalized so repeat flags of the same number collapse
+UPDATE flagged_phones fp
+SET phone = normalize_phone(fp.phone)
+WHERE normalize_phone(fp.phone) IS NOT NULL
+  AND fp.phone <> normalize_phone(fp.phone)
+  AND NOT EXISTS (SELECT 1 FROM flagged_phones o WHERE o.phone = normalize_phone(fp.phone));
+
+-- Look up provider phones by normalized number
+CREATE INDEX IF NOT EXISTS idx_provider_phones_normalized ON provider_phones(normalize_phone(phone));
+CREATE INDEX IF NOT EXISTS idx_provider_phones_corrected_normalized
+    ON provider_phones(normalize_phone(corrected_phone)) WHERE corrected_phone IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_flagged_phones_type ON flagged_phones(flag_type) WHERE is_active;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS address_propagation_targets;
+DROP TABLE IF EXISTS address_propagations;
+DROP INDEX IF EXISTS idx_provider_addresses_normalized;
+DROP FUNCTION IF EXISTS normalize_address(TEXT, TEXT, TEXT, TEXT, TEXT);
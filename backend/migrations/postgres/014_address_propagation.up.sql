@@ -0,0 +1,40 @@
+-- Comparable form of a street address: lower case, punctuation and repeated
+-- spaces collapsed, ZIP cut to five digits
+CREATE OR REPLACE FUNCTION normalize_address(address1 TEXT, address2 TEXT, city TEXT, state TEXT, zip TEXT)
+RETURNS TEXT AS $$
+    SELECT btrim(lower(regexp_replace(
+        COALESCE(address1, '') || ' ' || COALESCE(address2, '') || ' ' ||
+        COALESCE(city, '') || ' ' || COALESCE(state, '') || ' ' ||
+        left(regexp_replace(COALESCE(zip, ''), '\D', '', 'g'), 5),
+        '[^a-zA-Z0-9]+', ' ', 'g')));
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX IF NOT EXISTS idx_provider_addresses_normalized
+    ON provider_addresses(normalize_address(address1, address2, city, state, zip));
+
+-- One row per propagated address validation, so supervisors can undo it.
+-- source_session_id is not a foreign key because validation_sessions is
+-- partitioned and only unique on (id, created_at).
+CREATE TABLE IF NOT EXISTS address_propagations (
+    id SERIAL PRIMARY KEY,
+    source_address_id INTEGER NOT NULL REFERENCES provider_addresses(id) ON DELETE CASCADE,
+    source_provider_id INTEGER NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    source_session_id INTEGER,
+    within_group BOOLEAN NOT NULL DEFAULT false,
+    target_count INTEGER NOT NULL DEFAULT 0,
+    propagated_by INTEGER NOT NULL REFERENCES users(id),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    undone_by INTEGER REFERENCES users(id),
+    undone_at TIMESTAMPTZ,
+    restored_count INTEGER
+);
+
+CREATE INDEX IF NOT EXISTS idx_address_propagations_created ON address_propagations(created_at DESC);
+
+-- Each address a propagation changed, with its state beforehand
+CREATE TABLE IF NOT EXISTS address_propagation_targets (
+    propagation_id INTEGER NOT NULL REFERENCES address_propagations(id) ON DELETE CASCADE,
+    address_id INTEGER NOT NULL REFERENCES provider_addresses(id) ON DELETE CASCADE,
+    previous JSONB NOT NULL,
+    PRIMARY KEY (propagation_id, address_id)
+);
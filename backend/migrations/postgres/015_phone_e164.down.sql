@@ -0,0 +1,28 @@
+DROP INDEX IF EXISTS idx_provider_phones_corrected_normalized;
+DROP INDEX IF EXISTS idx_provider_phones_normalized;
+
+ALTER TABLE provider_phones DISABLE TRIGGER update_provider_phones_updated_at;
+
+UPDATE provider_phones
+SET phone = '(' || substr(phone, 3, 3) || ') ' || substr(phone, 6, 3) || '-' || substr(phone, 9, 4)
+WHERE phone ~ '^\+1[0-9]{10}$';
+
+UPDATE provider_phones
+SET corrected_phone = '(' || substr(corrected_phone, 3, 3) || ') ' || substr(corrected_phone, 6, 3) || '-' || substr(corrected_phone, 9, 4)
+WHERE corrected_phone ~ '^\+1[0-9]{10}$';
+
+ALTER TABLE provider_phones ENABLE TRIGGER update_provider_phones_updated_at;
+
+UPDATE flagged_phones SET phone = substr(phone, 3) WHERE phone ~ '^\+1[0-9]{10}$';
+
+CREATE OR REPLACE FUNCTION normalize_phone(value TEXT)
+RETURNS TEXT AS $$
+    SELECT NULLIF(
+        CASE WHEN length(d) = 11 AND left(d, 1) = '1' THEN substr(d, 2) ELSE d END,
+        '')
+    FROM (SELECT regexp_replace(COALESCE(value, ''), '\D', '', 'g') AS d) digits;
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX IF NOT EXISTS idx_provider_phones_normalized ON provider_phones(normalize_phone(phone));
+CREATE INDEX IF NOT EXISTS idx_provider_phones_corrected_normalized
+    ON provider_phones(normalize_phone(corrected_phone)) WHERE corrected_phone IS NOT NULL;
@@ -0,0 +1,37 @@
+-- Phone numbers are stored in E.164 ('+1' and the ten NANP digits), the form
+-- internal/phone produces. normalize_phone returns the same form, or NULL
+-- when the value is not a ten-digit NANP number.
+DROP INDEX IF EXISTS idx_provider_phones_corrected_normalized;
+DROP INDEX IF EXISTS idx_provider_phones_normalized;
+
+CREATE OR REPLACE FUNCTION normalize_phone(value TEXT)
+RETURNS TEXT AS $$
+    SELECT CASE WHEN d ~ '^[2-9][0-9]{9}$' THEN '+1' || d END
+    FROM (
+        SELECT CASE WHEN length(raw) = 11 AND left(raw, 1) = '1' THEN substr(raw, 2) ELSE raw END AS d
+        FROM (SELECT regexp_replace(COALESCE(value, ''), '\D', '', 'g') AS raw) stripped
+    ) digits;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Rewriting the format is not an edit; leave updated_at alone
+ALTER TABLE provider_phones DISABLE TRIGGER update_provider_phones_updated_at;
+
+UPDATE provider_phones
+SET phone = normalize_phone(phone)
+WHERE normalize_phone(phone) IS NOT NULL AND phone <> normalize_phone(phone);
+
+UPDATE provider_phones
+SET corrected_phone = normalize_phone(corrected_phone)
+WHERE normalize_phone(corrected_phone) IS NOT NULL AND corrected_phone <> normalize_phone(corrected_phone);
+
+ALTER TABLE provider_phones ENABLE TRIGGER update_provider_phones_updated_at;
+
+UPDATE flagged_phones fp
+SET phone = normalize_phone(fp.phone)
+WHERE normalize_phone(fp.phone) IS NOT NULL
+  AND fp.phone <> normalize_phone(fp.phone)
+  AND NOT EXISTS (SELECT 1 FROM flagged_phones o WHERE o.phone = normalize_phone(fp.phone));
+
+CREATE INDEX IF NOT EXISTS idx_provider_phones_normalized ON provider_phones(normalize_phone(phone));
+CREATE INDEX IF NOT EXISTS idx_provider_phones_corrected_normalized
+    ON provider_phones(normalize_phone(corrected_phone)) WHERE corrected_phone IS NOT NULL;
@@ -0,0 +1,37 @@
+CREATE OR REPLACE FUNCTION search_providers(
+    search_term TEXT,
+    limit_count INTEGER DEFAULT 50,
+    offset_count INTEGER DEFAULT 0
+)
+RETURNS TABLE (
+    id INTEGER,
+    uuid UUID,
+    npi VARCHAR,
+    provider_name VARCHAR,
+    specialty VARCHAR,
+    provider_group VARCHAR,
+    rank REAL
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        p.id,
+        p.uuid,
+        p.npi,
+        p.provider_name,
+        p.specialty,
+        p.provider_group,
+        ts_rank_cd(p.search_vector, plainto_tsquery('english', search_term)) as rank
+    FROM providers p
+    WHERE p.search_vector @@ plainto_tsquery('english', search_term)
+       OR p.npi ILIKE '%' || search_term || '%'
+       OR p.provider_name ILIKE '%' || search_term || '%'
+    ORDER BY rank DESC, p.provider_name
+    LIMIT limit_count
+    OFFSET offset_count;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_providers_group_trgm;
+DROP INDEX IF EXISTS idx_providers_name_trgm;
+DROP INDEX IF EXISTS idx_providers_npi_prefix;
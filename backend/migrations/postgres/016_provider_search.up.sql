@@ -0,0 +1,8 @@
+-- Prefix and substring lookups for the provider search API
+CREATE INDEX IF NOT EXISTS idx_providers_npi_prefix ON providers(npi varchar_pattern_ops);
+CREATE INDEX IF NOT EXISTS idx_providers_name_trgm ON providers USING gin(provider_name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_providers_group_trgm ON providers USING gin(provider_group gin_trgm_ops);
+
+-- GET /api/providers/search builds its query in providers.SearchProviders;
+-- nothing calls the original search function, which ignored is_active
+DROP FUNCTION IF EXISTS search_providers(TEXT, INTEGER, INTEGER);
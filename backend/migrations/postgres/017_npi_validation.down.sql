@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS npi_quarantine;
+DROP FUNCTION IF EXISTS npi_is_valid(TEXT);
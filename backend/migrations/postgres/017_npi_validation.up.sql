@@ -0,0 +1,24 @@
+-- NPI check: Luhn over the 80840 prefix followed by the ten NPI digits
+CREATE OR REPLACE FUNCTION npi_is_valid(npi TEXT)
+RETURNS BOOLEAN AS $$
+    SELECT CASE WHEN npi ~ '^\d{10}$' THEN (
+        SELECT SUM(CASE WHEN i % 2 = 0 THEN (d * 2) / 10 + (d * 2) % 10 ELSE d END) % 10 = 0
+        FROM (
+            SELECT i, substr('80840' || npi, 16 - i, 1)::integer AS d
+            FROM generate_series(1, 15) AS i
+        ) digits
+    ) ELSE false END;
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Import rows the loader set aside because their NPI failed the check
+CREATE TABLE IF NOT EXISTS npi_quarantine (
+    id SERIAL PRIMARY KEY,
+    npi TEXT NOT NULL, -- as found in the file
+    provider_name VARCHAR(500),
+    reason TEXT NOT NULL,
+    record JSONB NOT NULL, -- the raw CSV fields
+    row_number INTEGER,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_npi_quarantine_npi ON npi_quarantine(npi);
@@ -0,0 +1,14 @@
+CREATE OR REPLACE FUNCTION normalize_address(address1 TEXT, address2 TEXT, city TEXT, state TEXT, zip TEXT)
+RETURNS TEXT AS $$
+    SELECT btrim(lower(regexp_replace(
+        COALESCE(address1, '') || ' ' || COALESCE(address2, '') || ' ' ||
+        COALESCE(city, '') || ' ' || COALESCE(state, '') || ' ' ||
+        left(regexp_replace(COALESCE(zip, ''), '\D', '', 'g'), 5),
+        '[^a-zA-Z0-9]+', ' ', 'g')));
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX IF NOT EXISTS idx_provider_addresses_normalized
+    ON provider_addresses(normalize_address(address1, address2, city, state, zip));
+
+DROP INDEX IF EXISTS idx_provider_addresses_key;
+ALTER TABLE provider_addresses DROP COLUMN IF EXISTS address_key;
@@ -0,0 +1,11 @@
+-- Duplicate detection and propagation compare address_key, written by the
+-- application from address.Key, so they use the same USPS standardization as
+-- imports and corrections. Rows loaded before this get their key from
+-- POST /api/admin/addresses/standardize; until then they match nothing.
+ALTER TABLE provider_addresses ADD COLUMN IF NOT EXISTS address_key TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_provider_addresses_key
+    ON provider_addresses(address_key) WHERE address_key IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_provider_addresses_normalized;
+DROP FUNCTION IF EXISTS normalize_address(TEXT, TEXT, TEXT, TEXT, TEXT);
@@ -0,0 +1,29 @@
+-- Restore the auto-completing trigger function from 001
+CREATE OR REPLACE FUNCTION auto_lock_validation_session()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Automatically set locked_by when session is created or updated
+    IF NEW.status = 'in_progress' AND NEW.locked_by IS NULL THEN
+        NEW.locked_by = NEW.user_id;
+        NEW.locked_at = CURRENT_TIMESTAMP;
+    END IF;
+
+    -- Auto-complete validation if all required fields are validated
+    IF NEW.status = 'in_progress' AND
+       EXISTS (
+           SELECT 1 FROM provider_addresses pa
+           WHERE pa.provider_id = NEW.provider_id
+           AND pa.is_correct IS NOT NULL
+       ) AND
+       EXISTS (
+           SELECT 1 FROM provider_phones pp
+           WHERE pp.provider_id = NEW.provider_id
+           AND pp.is_correct IS NOT NULL
+       ) THEN
+        NEW.status = 'completed';
+        NEW.completed_at = CURRENT_TIMESTAMP;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,16 @@
+-- Sessions complete only through CompleteValidation, which scores them and
+-- records the results. Completing them here whenever any address and any
+-- phone had been validated closed re-claimed providers with partial work and
+-- made the explicit completion fail.
+CREATE OR REPLACE FUNCTION auto_lock_validation_session()
+RETURNS TRIGGER AS $$
+BEGIN
+    -- Automatically set locked_by when session is created or updated
+    IF NEW.status = 'in_progress' AND NEW.locked_by IS NULL THEN
+        NEW.locked_by = NEW.user_id;
+        NEW.locked_at = CURRENT_TIMESTAMP;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,67 @@
+-- Restore the weekday-only window and the eligibility check inlined in
+-- refresh_work_queue, as left by 011
+DROP FUNCTION IF EXISTS within_calling_window(TEXT, TEXT[], TIME, TIME, DATE[]);
+
+-- Whether it is currently a weekday within [window_start, window_end) in tz.
+-- Providers without a known time zone are always callable.
+CREATE OR REPLACE FUNCTION within_calling_window(tz TEXT, window_start TIME, window_end TIME)
+RETURNS BOOLEAN AS $$
+    SELECT tz IS NULL OR (
+        EXTRACT(ISODOW FROM CURRENT_TIMESTAMP AT TIME ZONE tz) BETWEEN 1 AND 5
+        AND (CURRENT_TIMESTAMP AT TIME ZONE tz)::time >= window_start
+        AND (CURRENT_TIMESTAMP AT TIME ZONE tz)::time < window_end
+    );
+$$ LANGUAGE sql STABLE;
+
+-- Add, update or remove a provider's queue entry based on its current state
+CREATE OR REPLACE FUNCTION refresh_work_queue(target_provider_id INTEGER)
+RETURNS void AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM providers p
+        WHERE p.id = target_provider_id
+          AND p.is_active = true
+          AND p.disposition IS NULL
+          AND EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id)
+          AND EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id)
+          AND (EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id AND pa.is_correct IS NULL)
+               OR EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id AND pp.is_correct IS NULL))
+          AND NOT EXISTS (
+              SELECT 1 FROM validation_sessions vs
+              WHERE vs.provider_id = p.id AND vs.status IN ('in_progress', 'on_hold')
+          )
+    ) THEN
+        INSERT INTO work_queue (provider_id, base_priority, priority, due_date, queued_at,
+                                states, specialty, languages, timezone)
+        SELECT p.id, p.priority, p.priority, p.due_date, p.created_at,
+               ARRAY(SELECT DISTINCT pa.state FROM provider_addresses pa
+                     WHERE pa.provider_id = p.id AND pa.state IS NOT NULL),
+               LOWER(p.specialty),
+               ARRAY(SELECT LOWER(lang) FROM jsonb_array_elements_text(
+                     CASE WHEN jsonb_typeof(p.metadata->'languages') = 'array'
+                          THEN p.metadata->'languages' ELSE '[]'::jsonb END) AS lang),
+               provider_timezone(p.id)
+        FROM providers p
+        WHERE p.id = target_provider_id
+        ON CONFLICT (provider_id) DO UPDATE SET
+            -- keep any aging bonus already earned
+            priority = LEAST(10, EXCLUDED.base_priority + (work_queue.priority - work_queue.base_priority)),
+            base_priority = EXCLUDED.base_priority,
+            due_date = EXCLUDED.due_date,
+            states = EXCLUDED.states,
+            specialty = EXCLUDED.specialty,
+            languages = EXCLUDED.languages,
+            timezone = EXCLUDED.timezone;
+    ELSE
+        DELETE FROM work_queue WHERE provider_id = target_provider_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS work_queue_eligible(INTEGER);
+
+-- Re-queue when an address moves to another ZIP as well as another state
+DROP TRIGGER IF EXISTS sync_work_queue_addresses ON provider_addresses;
+CREATE TRIGGER sync_work_queue_addresses
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, is_correct, state, zip ON provider_addresses
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
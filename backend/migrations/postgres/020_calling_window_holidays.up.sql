@@ -0,0 +1,90 @@
+-- Corrections to the calling window (010) and queue eligibility (011):
+-- the window skips federal holidays and admin closures, not only weekends;
+-- eligibility moves into work_queue_eligible; and corrected states and ZIPs
+-- re-queue a provider, since they change its time zone. The old three-argument
+-- window is dropped so no caller keeps the weekday-only check.
+DROP FUNCTION IF EXISTS within_calling_window(TEXT, TIME, TIME);
+
+-- Whether it is currently a business day in tz and within [window_start,
+-- window_end). holidays are the observed federal holidays, computed in code;
+-- admin closures apply when nationwide or in one of states. Providers without
+-- a known time zone are always callable.
+CREATE OR REPLACE FUNCTION within_calling_window(tz TEXT, states TEXT[], window_start TIME, window_end TIME, holidays DATE[])
+RETURNS BOOLEAN AS $$
+    SELECT tz IS NULL OR (
+        EXTRACT(ISODOW FROM l.local_now) BETWEEN 1 AND 5
+        AND l.local_now::time >= window_start
+        AND l.local_now::time < window_end
+        AND NOT (l.local_now::date = ANY(COALESCE(holidays, '{}')))
+        AND NOT EXISTS (
+            SELECT 1 FROM business_closures bc
+            WHERE bc.closure_date = l.local_now::date
+              AND (bc.state IS NULL OR bc.state::text = ANY(states))
+        )
+    )
+    FROM (SELECT CURRENT_TIMESTAMP AT TIME ZONE tz AS local_now) l;
+$$ LANGUAGE sql STABLE;
+
+-- Whether a provider belongs in the work queue: active and not disposed, with
+-- addresses and phones, something left to validate and no open session.
+-- Later changes to eligibility replace this function rather than
+-- refresh_work_queue.
+CREATE OR REPLACE FUNCTION work_queue_eligible(target_provider_id INTEGER)
+RETURNS BOOLEAN AS $$
+    SELECT EXISTS (
+        SELECT 1 FROM providers p
+        WHERE p.id = target_provider_id
+          AND p.is_active = true
+          AND p.disposition IS NULL
+          AND EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id)
+          AND EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id)
+          AND (EXISTS (SELECT 1 FROM provider_addresses pa WHERE pa.provider_id = p.id AND pa.is_correct IS NULL)
+               OR EXISTS (SELECT 1 FROM provider_phones pp WHERE pp.provider_id = p.id AND pp.is_correct IS NULL))
+          AND NOT EXISTS (
+              SELECT 1 FROM validation_sessions vs
+              WHERE vs.provider_id = p.id AND vs.status IN ('in_progress', 'on_hold')
+          )
+    );
+$$ LANGUAGE sql STABLE;
+
+-- Add, update or remove a provider's queue entry based on its current state
+CREATE OR REPLACE FUNCTION refresh_work_queue(target_provider_id INTEGER)
+RETURNS void AS $$
+BEGIN
+    IF work_queue_eligible(target_provider_id) THEN
+        INSERT INTO work_queue (provider_id, base_priority, priority, due_date, queued_at,
+                                states, specialty, languages, timezone)
+        SELECT p.id, p.priority, p.priority, p.due_date, p.created_at,
+               ARRAY(SELECT DISTINCT pa.state FROM provider_addresses pa
+                     WHERE pa.provider_id = p.id AND pa.state IS NOT NULL),
+               LOWER(p.specialty),
+               ARRAY(SELECT LOWER(lang) FROM jsonb_array_elements_text(
+                     CASE WHEN jsonb_typeof(p.metadata->'languages') = 'array'
+                          THEN p.metadata->'languages' ELSE '[]'::jsonb END) AS lang),
+               provider_timezone(p.id)
+        FROM providers p
+        WHERE p.id = target_provider_id
+        ON CONFLICT (provider_id) DO UPDATE SET
+            -- keep any aging bonus already earned
+            priority = LEAST(10, EXCLUDED.base_priority + (work_queue.priority - work_queue.base_priority)),
+            base_priority = EXCLUDED.base_priority,
+            due_date = EXCLUDED.due_date,
+            states = EXCLUDED.states,
+            specialty = EXCLUDED.specialty,
+            languages = EXCLUDED.languages,
+            timezone = EXCLUDED.timezone;
+    ELSE
+        DELETE FROM work_queue WHERE provider_id = target_provider_id;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Re-queue whenever an input of provider_timezone changes: the state or ZIP,
+-- their corrections, or which address counts as the practice
+DROP TRIGGER IF EXISTS sync_work_queue_addresses ON provider_addresses;
+CREATE TRIGGER sync_work_queue_addresses
+    AFTER INSERT OR DELETE OR UPDATE OF provider_id, is_correct, state, zip,
+        corrected_state, corrected_zip, address_category ON provider_addresses
+    FOR EACH ROW EXECUTE FUNCTION sync_work_queue_for_provider_row();
+
+UPDATE work_queue SET timezone = provider_timezone(provider_id);
@@ -4,18 +4,17 @@ SELECT
     COUNT(link_id) as addresses_with_link_id
 FROM provider_addresses;
 
--- Check for duplicate addresses for same provider
+-- Check for duplicate addresses for same provider (compared in standardized form)
 SELECT 
     pa.provider_id,
     p.npi,
     p.provider_name,
-    pa.address1,
-    pa.city,
-    pa.state,
+    normalize_address(pa.address1, pa.address2, pa.city, pa.state, pa.zip) as normalized_address,
     COUNT(*) as duplicate_count
 FROM provider_addresses pa
 JOIN providers p ON pa.provider_id = p.id
-GROUP BY pa.provider_id, p.npi, p.provider_name, pa.address1, pa.city, pa.state
+GROUP BY pa.provider_id, p.npi, p.provider_name,
+         normalize_address(pa.address1, pa.address2, pa.city, pa.state, pa.zip)
 HAVING COUNT(*) > 1
 LIMIT 10;
 
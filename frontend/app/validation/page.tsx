@@ -25,7 +25,7 @@ import { useProviderStore } from '@/lib/stores'
 
 // Utilities
 import { groupRecordsByAddress } from '@/lib/utils'
-import type { AddressValidation, PhoneValidation, ValidationPreview } from '@/lib/types'
+import type { AddressValidation, PhoneValidation, ValidationPreview, CallOutcome } from '@/lib/types'
 
 // Dialog state management
 interface DialogState {
@@ -136,9 +136,9 @@ export default function ValidationPage() {
     }
   }
   
-  const handleRecordCallAttempt = async (attemptNumber: number) => {
+  const handleRecordCallAttempt = async (attemptNumber: number, phoneId: number, outcome: CallOutcome) => {
     try {
-      await recordCallAttemptFromStore(attemptNumber)
+      await recordCallAttemptFromStore(attemptNumber, phoneId, outcome)
       await fetchStats()
     } catch (error) {
       console.error('Failed to record call attempt:', error)
@@ -353,7 +353,6 @@ export default function ValidationPage() {
                   <ValidationSteps
                     session={currentProvider.validation_session || null}
                     preview={validationPreview}
-                    onRecordCallAttempt={handleRecordCallAttempt}
                     onSaveProgress={handleSaveProgress}
                     onCompleteValidation={handleComplete}
                     onScrollToValidation={handleScrollToValidation}
@@ -363,6 +362,7 @@ export default function ValidationPage() {
                   {/* Call attempts */}
                   <CallAttemptsSection
                     session={currentProvider.validation_session || null}
+                    phones={currentProvider.phones ?? []}
                     onRecordAttempt={handleRecordCallAttempt}
                     isLoading={validationLoading}
                   />
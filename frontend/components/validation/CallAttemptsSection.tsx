@@ -1,23 +1,39 @@
 'use client'
 
+import { useState } from 'react'
 import { Button } from '@/components/ui/button'
 import { Card, CardContent, CardHeader, CardTitle } from '@/components/ui/card'
 import { Badge } from '@/components/ui/badge'
+import { Label } from '@/components/ui/label'
+import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from '@/components/ui/select'
 import { Clock, Phone, AlertCircle } from 'lucide-react'
 import { useCallAttempts } from '@/lib/hooks'
-import type { ValidationSession } from '@/lib/types'
+import type { ValidationSession, ProviderPhone, CallOutcome } from '@/lib/types'
+
+const OUTCOMES: { value: CallOutcome; label: string }[] = [
+  { value: 'successful', label: 'Reached provider' },
+  { value: 'no_answer', label: 'No answer' },
+  { value: 'busy', label: 'Busy' },
+  { value: 'disconnected', label: 'Disconnected' },
+  { value: 'invalid', label: 'Invalid number' }
+]
 
 interface CallAttemptsSectionProps {
   session: ValidationSession | null
-  onRecordAttempt: (attemptNumber: number) => Promise<void>
+  phones: ProviderPhone[]
+  onRecordAttempt: (attemptNumber: number, phoneId: number, outcome: CallOutcome) => Promise<void>
   isLoading: boolean
 }
 
 export function CallAttemptsSection({
   session,
+  phones,
   onRecordAttempt,
   isLoading
 }: CallAttemptsSectionProps) {
+  const [phoneId, setPhoneId] = useState<string>('')
+  const [outcome, setOutcome] = useState<CallOutcome | ''>('')
+
   const {
     callAttempts,
     canMakeCallAttempt,
@@ -27,9 +43,10 @@ export function CallAttemptsSection({
   } = useCallAttempts({ session })
   
   const handleRecordAttempt = async () => {
-    if (canMakeCallAttempt) {
+    if (canMakeCallAttempt && phoneId && outcome) {
       try {
-        await onRecordAttempt(nextAttemptNumber)
+        await onRecordAttempt(nextAttemptNumber, Number(phoneId), outcome)
+        setOutcome('')
       } catch (error) {
         // Error is handled by parent component, this ensures UI stays responsive
         console.error('Call attempt recording failed:', error)
@@ -81,6 +98,42 @@ export function CallAttemptsSection({
       </CardHeader>
       
       <CardContent className="space-y-4">
+        {/* Phone dialed and how the call ended */}
+        {canMakeCallAttempt && (
+          <div className="space-y-3">
+            <div className="space-y-2">
+              <Label>Phone Dialed</Label>
+              <Select value={phoneId} onValueChange={setPhoneId}>
+                <SelectTrigger>
+                  <SelectValue placeholder="Select phone" />
+                </SelectTrigger>
+                <SelectContent>
+                  {phones.map((phone) => (
+                    <SelectItem key={phone.id} value={String(phone.id)}>
+                      {phone.corrected_phone || phone.phone}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label>Outcome</Label>
+              <Select value={outcome} onValueChange={(value) => setOutcome(value as CallOutcome)}>
+                <SelectTrigger>
+                  <SelectValue placeholder="Select outcome" />
+                </SelectTrigger>
+                <SelectContent>
+                  {OUTCOMES.map(({ value, label }) => (
+                    <SelectItem key={value} value={value}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          </div>
+        )}
+
         {/* Attempt History */}
         <div className="space-y-3">
           {[1, 2].map((attemptNum) => {
@@ -121,7 +174,7 @@ export function CallAttemptsSection({
                   <Button
                     size="sm"
                     onClick={handleRecordAttempt}
-                    disabled={isLoading}
+                    disabled={isLoading || !phoneId || !outcome}
                     className="flex items-center gap-2"
                   >
                     <Clock className="h-4 w-4" />
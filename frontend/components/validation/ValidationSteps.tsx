@@ -12,7 +12,6 @@ interface ValidationStepsProps {
   session: ValidationSession | null
   preview: ValidationPreview | null
   className?: string
-  onRecordCallAttempt?: (attemptNumber: number) => Promise<void>
   onSaveProgress?: () => Promise<void>
   onCompleteValidation?: () => Promise<void>
   onScrollToValidation?: () => void
@@ -35,7 +34,6 @@ export function ValidationSteps({
   session, 
   preview, 
   className, 
-  onRecordCallAttempt, 
   onSaveProgress, 
   onCompleteValidation,
   onScrollToValidation,
@@ -54,30 +52,15 @@ export function ValidationSteps({
     const canComplete = preview?.can_complete ?? false
     const allItemsValidated = !hasUnvalidatedAddresses && !hasUnvalidatedPhones
 
-    // Determine if we can make a call attempt (business day logic)
-    const today = new Date()
-    const dayOfWeek = today.getDay()
-    const isBusinessDay = dayOfWeek >= 1 && dayOfWeek <= 5
-    const canMakeCallAttempt = !hasCallAttempt && isBusinessDay
-
     return [
       {
         id: 'call_attempt',
         title: 'Record Call Attempt',
-        description: 'Log the first call attempt to the provider',
+        description: 'Log the first call attempt from the Call Attempts card',
         icon: Phone,
         status: hasCallAttempt ? 'completed' : 'pending',
         required: true,
-        action: canMakeCallAttempt ? async () => {
-          setLoadingStep('call_attempt')
-          try {
-            await onRecordCallAttempt?.(1)
-          } finally {
-            setLoadingStep(null)
-          }
-        } : undefined,
-        actionText: 'Record Call',
-        canPerformAction: canMakeCallAttempt && !isLoading
+        canPerformAction: false
       },
       {
         id: 'validate_data',
@@ -9,7 +9,8 @@ import type {
   ValidationState,
   ProviderValidationData,
   ValidationPreview,
-  ApiError
+  ApiError,
+  CallOutcome
 } from '@/lib/types'
 
 interface UseValidationProps {
@@ -111,14 +112,16 @@ export function useValidation({ providerData }: UseValidationProps) {
     }
   }, [providerData, validationState, updateValidationApi])
   
-  const recordCallAttempt = useCallback(async (attemptNumber: number) => {
+  const recordCallAttempt = useCallback(async (attemptNumber: number, phoneId: number, outcome: CallOutcome) => {
     if (!providerData?.validation_session) {
       throw new Error('No validation session available')
     }
     
     try {
       return await recordCallAttemptApi.execute(providerData.validation_session.id, {
-        attempt_number: attemptNumber
+        attempt_number: attemptNumber,
+        phone_id: phoneId,
+        outcome
       })
     } catch (error: any) {
       // Transform backend SQL errors into user-friendly messages
@@ -5,7 +5,8 @@ import type {
   ProviderStats, 
   AddressValidation, 
   PhoneValidation, 
-  NewAddress 
+  NewAddress,
+  CallOutcome
 } from '@/lib/types'
 
 interface ProviderStoreState {
@@ -20,7 +21,7 @@ interface ProviderStoreState {
   // Actions
   fetchNextProvider: () => Promise<void>
   updateValidations: () => Promise<void>
-  recordCallAttempt: (attemptNumber: number) => Promise<void>
+  recordCallAttempt: (attemptNumber: number, phoneId: number, outcome: CallOutcome) => Promise<void>
   completeValidation: () => Promise<void>
   fetchStats: () => Promise<void>
   
@@ -107,7 +108,7 @@ export const useProviderStore = create<ProviderStoreState>((set, get) => ({
     }
   },
 
-  recordCallAttempt: async (attemptNumber: number) => {
+  recordCallAttempt: async (attemptNumber: number, phoneId: number, outcome: CallOutcome) => {
     const { currentData } = get()
     if (!currentData?.validation_session) {
       throw new Error('No validation session available')
@@ -116,7 +117,9 @@ export const useProviderStore = create<ProviderStoreState>((set, get) => ({
     set({ isLoading: true, error: null })
     try {
       await validationService.recordCallAttempt(currentData.validation_session.id, {
-        attempt_number: attemptNumber
+        attempt_number: attemptNumber,
+        phone_id: phoneId,
+        outcome
       })
       
       // Immediately update the local state with the current timestamp
@@ -34,8 +34,12 @@ export interface ValidationUpdate {
   new_phones?: NewPhone[]
 }
 
+export type CallOutcome = 'successful' | 'no_answer' | 'busy' | 'disconnected' | 'invalid'
+
 export interface CallAttemptRequest {
   attempt_number: number
+  phone_id: number
+  outcome: CallOutcome
 }
 
 export interface ValidationState {